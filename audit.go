@@ -0,0 +1,301 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	goduckdb "github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var ErrAuditPrimaryKey = errors.New("audited model must have a primary key")
+
+const auditOldRowsKey = "duckdb:audit_old_rows"
+
+// AuditOptions configures RegisterAuditTrigger.
+type AuditOptions struct {
+	// Columns lists the audited columns, all columns when empty. The primary key is always recorded.
+	Columns []string
+}
+
+// RegisterAuditTrigger emulates row triggers, which DuckDB lacks, with gorm callbacks recording every
+// create, update and delete of model's rows into <table>_audit, created if missing, with columns
+//
+//	id, operation (INSERT, UPDATE or DELETE), changed_at, old_values (JSON), new_values (JSON)
+//
+// The audit rows are written in the transaction of the change. Like any callback,
+// it only sees changes made through gorm, not raw SQL.
+func RegisterAuditTrigger(db *gorm.DB, model interface{}, opts AuditOptions) error {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	if stmt.Schema.PrioritizedPrimaryField == nil {
+		return fmt.Errorf("%w: %s", ErrAuditPrimaryKey, stmt.Table)
+	}
+
+	a := &auditor{
+		table:      stmt.Table,
+		auditTable: stmt.Table + "_audit",
+		primaryKey: stmt.Schema.PrioritizedPrimaryField,
+		columns:    map[string]bool{},
+	}
+	for _, column := range opts.Columns {
+		a.columns[column] = true
+	}
+
+	if err := db.Exec("CREATE SEQUENCE IF NOT EXISTS " + a.auditTable + "_id_seq START 1").Error; err != nil {
+		return err
+	}
+	if err := db.Exec("CREATE TABLE IF NOT EXISTS " + a.auditTable + " (" +
+		"id BIGINT PRIMARY KEY DEFAULT nextval('" + a.auditTable + "_id_seq'), " +
+		"operation VARCHAR NOT NULL, changed_at TIMESTAMPTZ NOT NULL, old_values JSON, new_values JSON)").Error; err != nil {
+		return err
+	}
+
+	var (
+		name    = "duckdb:audit:" + a.table
+		create  = db.Callback().Create()
+		update  = db.Callback().Update()
+		deletes = db.Callback().Delete()
+	)
+	callbacks := []struct {
+		name     string
+		fn       func(*gorm.DB)
+		get      func(name string) func(*gorm.DB)
+		replace  func(name string, fn func(*gorm.DB)) error
+		register func(name string, fn func(*gorm.DB)) error
+	}{
+		{name + ":create", a.afterCreate, create.Get, create.Replace, create.After("gorm:create").Register},
+		{name + ":before_update", a.captureOldRows, update.Get, update.Replace, update.Before("gorm:update").Register},
+		{name + ":update", a.afterUpdate, update.Get, update.Replace, update.After("gorm:update").Register},
+		{name + ":before_delete", a.captureOldRows, deletes.Get, deletes.Replace, deletes.Before("gorm:delete").Register},
+		{name + ":delete", a.afterDelete, deletes.Get, deletes.Replace, deletes.After("gorm:delete").Register},
+	}
+	for _, c := range callbacks {
+		register := c.register
+		if c.get(c.name) != nil {
+			register = c.replace
+		}
+		if err := register(c.name, c.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type auditor struct {
+	table      string
+	auditTable string
+	primaryKey *schema.Field
+	columns    map[string]bool
+}
+
+func (a *auditor) matches(tx *gorm.DB) bool {
+	return tx.Error == nil && tx.Statement.Schema != nil && tx.Statement.Table == a.table
+}
+
+// captureOldRows reads the rows an update or delete is about to change.
+func (a *auditor) captureOldRows(tx *gorm.DB) {
+	if !a.matches(tx) {
+		return
+	}
+
+	query := tx.Session(&gorm.Session{NewDB: true}).Table(a.table)
+	conditions := false
+	if c, ok := tx.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			query = query.Clauses(where)
+			conditions = true
+		}
+	}
+	if keys := a.keysOf(tx); len(keys) > 0 {
+		query = query.Where(clause.IN{Column: clause.Column{Name: a.primaryKey.DBName}, Values: keys})
+		conditions = true
+	}
+	if !conditions && !tx.AllowGlobalUpdate {
+		return
+	}
+
+	var rows []map[string]interface{}
+	if err := query.Find(&rows).Error; err != nil {
+		_ = tx.AddError(err)
+		return
+	}
+	tx.InstanceSet(auditOldRowsKey, rows)
+}
+
+func (a *auditor) afterCreate(tx *gorm.DB) {
+	if !a.matches(tx) {
+		return
+	}
+
+	rows, err := a.rowsByKeys(tx, a.keysOf(tx))
+	if err != nil {
+		_ = tx.AddError(err)
+		return
+	}
+	for _, row := range rows {
+		a.record(tx, "INSERT", nil, row)
+	}
+}
+
+func (a *auditor) afterUpdate(tx *gorm.DB) {
+	if !a.matches(tx) {
+		return
+	}
+
+	oldRows := a.oldRows(tx)
+	keys := make([]interface{}, len(oldRows))
+	for i, row := range oldRows {
+		keys[i] = row[a.primaryKey.DBName]
+	}
+	newRows, err := a.rowsByKeys(tx, keys)
+	if err != nil {
+		_ = tx.AddError(err)
+		return
+	}
+
+	byKey := make(map[string]map[string]interface{}, len(newRows))
+	for _, row := range newRows {
+		byKey[fmt.Sprint(row[a.primaryKey.DBName])] = row
+	}
+	for _, row := range oldRows {
+		a.record(tx, "UPDATE", row, byKey[fmt.Sprint(row[a.primaryKey.DBName])])
+	}
+}
+
+func (a *auditor) afterDelete(tx *gorm.DB) {
+	if !a.matches(tx) {
+		return
+	}
+	for _, row := range a.oldRows(tx) {
+		a.record(tx, "DELETE", row, nil)
+	}
+}
+
+func (a *auditor) oldRows(tx *gorm.DB) []map[string]interface{} {
+	if rows, ok := tx.InstanceGet(auditOldRowsKey); ok {
+		return rows.([]map[string]interface{})
+	}
+	return nil
+}
+
+// keysOf returns the non-zero primary keys of the statement's struct or slice of structs.
+func (a *auditor) keysOf(tx *gorm.DB) (keys []interface{}) {
+	rv := reflect.Indirect(tx.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Struct:
+		if key, zero := a.primaryKey.ValueOf(tx.Statement.Context, rv); !zero {
+			keys = append(keys, key)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elem := reflect.Indirect(rv.Index(i))
+			if elem.Kind() != reflect.Struct {
+				continue
+			}
+			if key, zero := a.primaryKey.ValueOf(tx.Statement.Context, elem); !zero {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return
+}
+
+func (a *auditor) rowsByKeys(tx *gorm.DB, keys []interface{}) (rows []map[string]interface{}, err error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	err = tx.Session(&gorm.Session{NewDB: true}).Table(a.table).
+		Where(clause.IN{Column: clause.Column{Name: a.primaryKey.DBName}, Values: keys}).
+		Find(&rows).Error
+	return
+}
+
+func (a *auditor) values(row map[string]interface{}) (interface{}, error) {
+	if row == nil {
+		return nil, nil
+	}
+
+	values := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		if len(a.columns) == 0 || a.columns[column] || column == a.primaryKey.DBName {
+			values[column] = jsonValue(value)
+		}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// jsonValue converts the driver types without a JSON form of their own, so DECIMAL columns are
+// written as JSON numbers and UUID columns as strings, also inside lists, structs and maps.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case goduckdb.Decimal:
+		if v.Value == nil {
+			return nil
+		}
+		return json.Number(v.String())
+	case goduckdb.UUID:
+		return v.String()
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, elem := range v {
+			values[i] = jsonValue(elem)
+		}
+		return values
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			values[key] = jsonValue(elem)
+		}
+		return values
+	}
+	return value
+}
+
+func (a *auditor) record(tx *gorm.DB, operation string, oldRow, newRow map[string]interface{}) {
+	oldValues, err := a.values(oldRow)
+	if err != nil {
+		_ = tx.AddError(err)
+		return
+	}
+	newValues, err := a.values(newRow)
+	if err != nil {
+		_ = tx.AddError(err)
+		return
+	}
+
+	if err := tx.Session(&gorm.Session{NewDB: true}).Exec(
+		"INSERT INTO ? (operation, changed_at, old_values, new_values) VALUES (?, ?, ?, ?)",
+		clause.Table{Name: a.auditTable}, operation, time.Now(), oldValues, newValues,
+	).Error; err != nil {
+		_ = tx.AddError(err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type AuditedItem struct {
+	ID    uint    `gorm:"column:id;primaryKey;autoIncrement"`
+	Name  string  `gorm:"column:name"`
+	Price float64 `gorm:"column:price"`
+	Note  string  `gorm:"column:note"`
+}
+
+type auditRow struct {
+	ID        uint
+	Operation string
+	ChangedAt time.Time
+	OldValues *string
+	NewValues *string
+}
+
+func decodeAuditValues(t *testing.T, data *string) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	var values map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(*data), &values))
+	return values
+}
+
+// TestRegisterAuditTrigger verifies creates, updates and deletes are recorded with the audited columns only.
+func TestRegisterAuditTrigger(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&AuditedItem{}))
+	assert.NoError(t, duckdb.RegisterAuditTrigger(db, &AuditedItem{}, duckdb.AuditOptions{Columns: []string{"name", "price"}}))
+	assert.True(t, db.Migrator().HasTable("audited_items_audit"))
+
+	items := []AuditedItem{{Name: "pen", Price: 1, Note: "blue"}, {Name: "ink", Price: 3, Note: "black"}}
+	assert.NoError(t, db.Create(&items).Error)
+	assert.NoError(t, db.Model(&items[0]).Update("price", 2).Error)
+	assert.NoError(t, db.Delete(&items[1]).Error)
+	assert.NoError(t, db.Where("name = ?", "missing").Delete(&AuditedItem{}).Error)
+
+	var rows []auditRow
+	assert.NoError(t, db.Raw("SELECT id, operation, changed_at, old_values::VARCHAR AS old_values, new_values::VARCHAR AS new_values "+
+		"FROM audited_items_audit ORDER BY id").Scan(&rows).Error)
+	if !assert.Len(t, rows, 4) {
+		return
+	}
+
+	operations := make([]string, len(rows))
+	for i, row := range rows {
+		operations[i] = row.Operation
+		assert.WithinDuration(t, time.Now(), row.ChangedAt, time.Minute)
+	}
+	assert.Equal(t, []string{"INSERT", "INSERT", "UPDATE", "DELETE"}, operations)
+
+	assert.Nil(t, rows[0].OldValues)
+	assert.Equal(t, map[string]interface{}{"id": float64(items[0].ID), "name": "pen", "price": float64(1)}, decodeAuditValues(t, rows[0].NewValues))
+
+	assert.Equal(t, float64(1), decodeAuditValues(t, rows[2].OldValues)["price"])
+	assert.Equal(t, float64(2), decodeAuditValues(t, rows[2].NewValues)["price"])
+	assert.NotContains(t, decodeAuditValues(t, rows[2].NewValues), "note")
+
+	assert.Equal(t, "ink", decodeAuditValues(t, rows[3].OldValues)["name"])
+	assert.Nil(t, rows[3].NewValues)
+}
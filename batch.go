@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrBatchUpdateColumns = errors.New("batch update rows must have the same columns, including the key column")
+
+// BatchUpdate updates many rows of model's table in one statement:
+//
+//	UPDATE t SET col = v.col FROM (VALUES (...), ...) AS v(key, col) WHERE t.key = v.key
+//
+// Every map in updates holds the key column and the same set of columns to set,
+// keys may be column or field names. It returns the number of updated rows.
+func BatchUpdate(db *gorm.DB, model interface{}, keyCol string, updates []map[string]interface{}) (int64, error) {
+	if len(updates) == 0 {
+		return 0, nil
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return 0, err
+	}
+
+	dbName := func(name string) string {
+		if field := stmt.Schema.LookUpField(name); field != nil && field.DBName != "" {
+			return field.DBName
+		}
+		return name
+	}
+
+	key := dbName(keyCol)
+	rows := make([]map[string]interface{}, len(updates))
+	for i, update := range updates {
+		row := make(map[string]interface{}, len(update))
+		for name, value := range update {
+			row[dbName(name)] = value
+		}
+		rows[i] = row
+	}
+
+	var columns []string
+	for name := range rows[0] {
+		if name != key {
+			columns = append(columns, name)
+		}
+	}
+	sort.Strings(columns)
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("%w: no column to update", ErrBatchUpdateColumns)
+	}
+
+	values := make([]interface{}, len(rows))
+	for i, row := range rows {
+		if _, ok := row[key]; !ok || len(row) != len(columns)+1 {
+			return 0, fmt.Errorf("%w: row %d", ErrBatchUpdateColumns, i)
+		}
+		value := []interface{}{row[key]}
+		for _, column := range columns {
+			v, ok := row[column]
+			if !ok {
+				return 0, fmt.Errorf("%w: row %d misses %s", ErrBatchUpdateColumns, i, column)
+			}
+			value = append(value, v)
+		}
+		values[i] = value
+	}
+
+	var (
+		sql   strings.Builder
+		vars  = []interface{}{clause.Table{Name: stmt.Table}}
+		alias = []interface{}{clause.Column{Name: key}}
+	)
+	sql.WriteString("UPDATE ? SET ")
+	for i, column := range columns {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString("? = ?")
+		vars = append(vars, clause.Column{Name: column}, clause.Column{Table: "v", Name: column})
+		alias = append(alias, clause.Column{Name: column})
+	}
+
+	sql.WriteString(" FROM (VALUES ")
+	for i := range values {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString("?")
+	}
+	vars = append(vars, values...)
+
+	sql.WriteString(") AS v? WHERE ? = ?")
+	vars = append(vars, alias, clause.Column{Table: stmt.Table, Name: key}, clause.Column{Table: "v", Name: key})
+
+	result := db.Exec(sql.String(), vars...)
+	return result.RowsAffected, result.Error
+}
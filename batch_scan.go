@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidBatchSize = errors.New("batch size must be positive")
+
+// BatchScan runs the query of db in batches of batchSize rows, scanning each batch into dest,
+// a pointer to a slice that is reused between batches, and calling fn with it.
+//
+// When dest's model has a primary key and db has no ORDER BY, batches are read by key,
+// WHERE pk > last_pk ORDER BY pk LIMIT n, which stays fast for late batches.
+// Otherwise they are read with LIMIT n OFFSET m, so the query should be ordered deterministically.
+func BatchScan(db *gorm.DB, dest interface{}, batchSize int, fn func(batch interface{}) error) error {
+	if batchSize <= 0 {
+		return ErrInvalidBatchSize
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if _, ordered := db.Statement.Clauses["ORDER BY"]; !ordered && stmt.Parse(dest) == nil && stmt.Schema.PrioritizedPrimaryField != nil {
+		return batchScanByKey(db, dest, batchSize, fn, stmt)
+	}
+
+	base := db.Session(&gorm.Session{})
+	for offset := 0; ; offset += batchSize {
+		result := base.Limit(batchSize).Offset(offset).Find(dest)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := fn(dest); err != nil {
+			return err
+		}
+		if result.RowsAffected < int64(batchSize) {
+			return nil
+		}
+	}
+}
+
+func batchScanByKey(db *gorm.DB, dest interface{}, batchSize int, fn func(batch interface{}) error, stmt *gorm.Statement) error {
+	pk := stmt.Schema.PrioritizedPrimaryField
+	column := clause.Column{Table: clause.CurrentTable, Name: pk.DBName}
+	base := db.Session(&gorm.Session{}).Order(clause.OrderByColumn{Column: column}).Limit(batchSize)
+
+	var lastKey interface{}
+	for {
+		query := base
+		if lastKey != nil {
+			query = query.Where(clause.Gt{Column: column, Value: lastKey})
+		}
+
+		result := query.Find(dest)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := fn(dest); err != nil {
+			return err
+		}
+		if result.RowsAffected < int64(batchSize) {
+			return nil
+		}
+
+		rows := reflect.Indirect(reflect.ValueOf(dest))
+		lastKey, _ = pk.ValueOf(db.Statement.Context, reflect.Indirect(rows.Index(rows.Len()-1)))
+	}
+}
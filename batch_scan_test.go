@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestBatchScan verifies every row is visited exactly once, both by key and by offset.
+func TestBatchScan(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 100000)
+
+	check := func(ordered bool) {
+		seen := make(map[int]int, 100000)
+		batches := 0
+
+		query := db.Model(&SampleRow{})
+		if ordered {
+			query = query.Order("id DESC")
+		}
+
+		var rows []SampleRow
+		err := duckdb.BatchScan(query, &rows, 1000, func(batch interface{}) error {
+			batches++
+			assert.LessOrEqual(t, len(*batch.(*[]SampleRow)), 1000)
+			for _, row := range *batch.(*[]SampleRow) {
+				seen[row.ID]++
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 100, batches)
+		assert.Len(t, seen, 100000)
+		for id, count := range seen {
+			if count != 1 {
+				t.Fatalf("row %d visited %d times", id, count)
+			}
+		}
+	}
+	check(false)
+	check(true)
+
+	assert.ErrorIs(t, duckdb.BatchScan(db.Model(&SampleRow{}), &[]SampleRow{}, 0, nil), duckdb.ErrInvalidBatchSize)
+}
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type BatchRow struct {
+	ID    uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Name  string `gorm:"column:name"`
+	Score int    `gorm:"column:score"`
+}
+
+// TestBatchUpdate verifies updated rows get their own values and the other rows are untouched.
+func TestBatchUpdate(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&BatchRow{}))
+
+	rows := make([]BatchRow, 1000)
+	for i := range rows {
+		rows[i] = BatchRow{Name: fmt.Sprintf("row-%d", i+1), Score: i + 1}
+	}
+	assert.NoError(t, db.CreateInBatches(&rows, 200).Error)
+
+	var updates []map[string]interface{}
+	for id := 2; id <= 1000; id += 2 {
+		updates = append(updates, map[string]interface{}{
+			"id":    id,
+			"Name":  fmt.Sprintf("updated-%d", id),
+			"score": id * 10,
+		})
+	}
+
+	affected, err := duckdb.BatchUpdate(db, &BatchRow{}, "id", updates)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), affected)
+
+	var result []BatchRow
+	assert.NoError(t, db.Order("id").Find(&result).Error)
+	assert.Len(t, result, 1000)
+	for _, row := range result {
+		if row.ID%2 == 0 {
+			assert.Equal(t, BatchRow{ID: row.ID, Name: fmt.Sprintf("updated-%d", row.ID), Score: int(row.ID) * 10}, row)
+		} else {
+			assert.Equal(t, BatchRow{ID: row.ID, Name: fmt.Sprintf("row-%d", row.ID), Score: int(row.ID)}, row)
+		}
+	}
+
+	_, err = duckdb.BatchUpdate(db, &BatchRow{}, "id", []map[string]interface{}{{"id": 1, "score": 1}, {"id": 3}})
+	assert.ErrorIs(t, err, duckdb.ErrBatchUpdateColumns)
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidRuns = errors.New("benchmark runs must be positive")
+
+// PlanJSON is the JSON profile EXPLAIN ANALYZE returns for one run, with the operator tree and timings.
+type PlanJSON json.RawMessage
+
+// MarshalJSON returns the plan as is.
+func (p PlanJSON) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(p).MarshalJSON()
+}
+
+// BenchmarkResult holds the latency percentiles in milliseconds and the plan of each run.
+type BenchmarkResult struct {
+	MedianMs float64
+	P95Ms    float64
+	P99Ms    float64
+	Plans    []PlanJSON
+}
+
+// BenchmarkQuery runs query with args runs times under EXPLAIN (ANALYZE, FORMAT JSON),
+// measuring each run's wall-clock latency and collecting its profile.
+func BenchmarkQuery(db *gorm.DB, query string, args []interface{}, runs int) (*BenchmarkResult, error) {
+	if runs <= 0 {
+		return nil, ErrInvalidRuns
+	}
+
+	result := &BenchmarkResult{Plans: make([]PlanJSON, 0, runs)}
+	latencies := make([]float64, 0, runs)
+	for i := 0; i < runs; i++ {
+		start := time.Now()
+		plan, err := explainAnalyze(db, query, args)
+		if err != nil {
+			return nil, err
+		}
+		latencies = append(latencies, float64(time.Since(start))/float64(time.Millisecond))
+		result.Plans = append(result.Plans, plan)
+	}
+
+	sort.Float64s(latencies)
+	result.MedianMs = percentile(latencies, 0.5)
+	result.P95Ms = percentile(latencies, 0.95)
+	result.P99Ms = percentile(latencies, 0.99)
+	return result, nil
+}
+
+// explainAnalyze returns the JSON profile, the last column of the (explain_key, explain_value) rows.
+func explainAnalyze(db *gorm.DB, query string, args []interface{}) (PlanJSON, error) {
+	rows, err := db.Raw("EXPLAIN (ANALYZE, FORMAT JSON) "+query, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plan PlanJSON
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		plan = PlanJSON(value)
+	}
+	return plan, rows.Err()
+}
+
+// percentile interpolates the p-th percentile of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
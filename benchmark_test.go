@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestBenchmarkQuery verifies the latency percentiles of a known query and that every run returns a JSON plan.
+func TestBenchmarkQuery(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 10000)
+
+	result, err := duckdb.BenchmarkQuery(db, "SELECT count(*) FROM sample_rows WHERE id > ?", []interface{}{5000}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, result.Plans, 10)
+	assert.Less(t, result.MedianMs, 500.0)
+	assert.LessOrEqual(t, result.MedianMs, result.P95Ms)
+	assert.LessOrEqual(t, result.P95Ms, result.P99Ms)
+
+	for _, plan := range result.Plans {
+		assert.True(t, json.Valid(plan), string(plan))
+	}
+
+	_, err = duckdb.BenchmarkQuery(db, "SELECT 1", nil, 0)
+	assert.ErrorIs(t, err, duckdb.ErrInvalidRuns)
+}
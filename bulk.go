@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CSVOptions configures the CSV dialect of BulkLoader, the zero value reads and writes
+// comma separated files without header.
+type CSVOptions struct {
+	Delimiter  string
+	Header     bool
+	NullString string
+	// DateFormat is a strftime format like %d/%m/%Y for DATE columns.
+	DateFormat string
+}
+
+func (opts CSVOptions) build() string {
+	options := []string{"FORMAT CSV"}
+	for _, option := range opts.options() {
+		options = append(options, option[0]+" "+option[1])
+	}
+	return "(" + strings.Join(options, ", ") + ")"
+}
+
+// options returns the names and SQL values of the options, shared by COPY and read_csv.
+func (opts CSVOptions) options() [][2]string {
+	options := [][2]string{{"header", strconv.FormatBool(opts.Header)}}
+	if opts.Delimiter != "" {
+		options = append(options, [2]string{"delim", stringLiteral(opts.Delimiter).SQL})
+	}
+	if opts.NullString != "" {
+		options = append(options, [2]string{"nullstr", stringLiteral(opts.NullString).SQL})
+	}
+	if opts.DateFormat != "" {
+		options = append(options, [2]string{"dateformat", stringLiteral(opts.DateFormat).SQL})
+	}
+	return options
+}
+
+// BulkLoader moves whole tables between DuckDB and files with COPY, which is much faster than
+// inserting rows through GORM. Unlike BulkImport it takes models as well as table names.
+type BulkLoader struct {
+	db *gorm.DB
+}
+
+func NewBulkLoader(db *gorm.DB) *BulkLoader {
+	return &BulkLoader{db: db}
+}
+
+// CopyFromCSV appends the rows of the CSV file at filePath to the table of dst, a model or a table name.
+func (b *BulkLoader) CopyFromCSV(dst interface{}, filePath string, opts CSVOptions) error {
+	return runWithTable(b.db, dst, func(table clause.Table) error {
+		return BulkImport(b.db, table.Name, filePath, BulkImportOptions{
+			Header:     opts.Header,
+			Delimiter:  opts.Delimiter,
+			NullString: opts.NullString,
+			DateFormat: opts.DateFormat,
+		})
+	})
+}
+
+// CopyToCSV writes all rows of the table of dst, a model or a table name, to the CSV file at filePath.
+func (b *BulkLoader) CopyToCSV(dst interface{}, filePath string, opts CSVOptions) error {
+	return runWithTable(b.db, dst, func(table clause.Table) error {
+		return b.db.Exec("COPY ? TO ? "+opts.build(), table, stringLiteral(filePath)).Error
+	})
+}
+
+// runWithTable resolves the table of dst like the migrator does, so db.Table overrides and
+// TableName methods apply.
+func runWithTable(db *gorm.DB, dst interface{}, fc func(table clause.Table) error) error {
+	m, ok := db.Migrator().(Migrator)
+	if !ok {
+		return gorm.ErrNotImplemented
+	}
+	return m.RunWithValue(dst, func(stmt *gorm.Statement) error {
+		return fc(clause.Table{Name: stmt.Table})
+	})
+}
+
+type JSONFormat string
+
+const (
+	// JSONFormatAuto detects whether a file is newline-delimited or holds a JSON array.
+	JSONFormatAuto             JSONFormat = "auto"
+	JSONFormatNewlineDelimited JSONFormat = "newline_delimited"
+	JSONFormatArray            JSONFormat = "array"
+)
+
+// JSONOptions configures ImportJSON, the zero value detects the format of the file.
+type JSONOptions struct {
+	Format JSONFormat
+}
+
+// ImportJSON appends the objects of the JSON file at filePath to the table of dst, a model or a table name.
+// Object keys are matched to columns by name, nested objects and arrays load into STRUCT and LIST columns.
+func (b *BulkLoader) ImportJSON(dst interface{}, filePath string, opts JSONOptions) error {
+	format := opts.Format
+	if format == "" {
+		format = JSONFormatAuto
+	}
+	return runWithTable(b.db, dst, func(table clause.Table) error {
+		return b.db.Exec("INSERT INTO ? BY NAME SELECT * FROM read_json_auto(?, format = ?)",
+			table, stringLiteral(filePath), stringLiteral(string(format))).Error
+	})
+}
+
+// ExportToJSON writes the rows of the table of dst, a model or a table name, to the file at filePath
+// as newline-delimited JSON. where takes the arguments of gorm's Where to export only the matching rows.
+func (b *BulkLoader) ExportToJSON(dst interface{}, filePath string, where ...interface{}) error {
+	return copyTableTo(b.db, dst, filePath, FormatJSON, where)
+}
+
+// copyTableTo writes the rows of the table of dst matching where to filePath with COPY (query) TO.
+func copyTableTo(db *gorm.DB, dst interface{}, filePath string, format ExportFormat, where []interface{}) error {
+	return runWithTable(db, dst, func(table clause.Table) error {
+		query := db.Table(table.Name)
+		if len(where) > 0 {
+			query = query.Where(where[0], where[1:]...)
+		}
+		return db.Exec("COPY (?) TO ? (FORMAT "+string(format)+")", query, stringLiteral(filePath)).Error
+	})
+}
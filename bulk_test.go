@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestBulkLoaderCSV verifies a table round-trips through a CSV file written with custom dialect options.
+func TestBulkLoaderCSV(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Event{}))
+	day := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, db.Create(&[]Event{{Name: "launch", EventDate: day}, {Name: "", EventDate: day}}).Error)
+	assert.NoError(t, db.Exec("UPDATE events SET name = NULL WHERE name = ''").Error)
+
+	opts := duckdb.CSVOptions{Delimiter: "|", Header: true, NullString: "N/A", DateFormat: "%d/%m/%Y"}
+	loader := duckdb.NewBulkLoader(db)
+	file := filepath.Join(t.TempDir(), "events.csv")
+	assert.NoError(t, loader.CopyToCSV(&Event{}, file, opts))
+
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "id|name|event_date\n1|launch|15/03/2024\n2|N/A|15/03/2024\n", string(content))
+
+	assert.NoError(t, db.Exec("CREATE TABLE events_copy AS SELECT * FROM events LIMIT 0").Error)
+	assert.NoError(t, loader.CopyFromCSV("events_copy", file, opts))
+	assert.NoError(t, duckdb.NewBulkLoader(db.Table("events_copy")).CopyFromCSV(&Event{}, file, opts))
+
+	var events []Event
+	assert.NoError(t, db.Table("events_copy").Order("id").Find(&events).Error)
+	assert.Len(t, events, 4)
+	assert.Equal(t, "launch", events[0].Name)
+	assert.True(t, day.Equal(events[0].EventDate))
+
+	var nulls int64
+	assert.NoError(t, db.Table("events_copy").Where("name IS NULL").Count(&nulls).Error)
+	assert.Equal(t, int64(2), nulls)
+}
+
+// TestBulkLoaderJSON verifies STRUCT columns round-trip through newline-delimited JSON and JSON arrays load too,
+// from a path with a quote in it.
+func TestBulkLoaderJSON(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Landmark{}))
+	assert.NoError(t, db.Create(&[]Landmark{
+		{Name: "tower", Location: GeoPoint{X: 2.2945, Y: 48.8584}},
+		{Name: "bridge", Location: GeoPoint{X: -122.4783, Y: 37.8199}},
+	}).Error)
+
+	loader := duckdb.NewBulkLoader(db)
+	file := filepath.Join(t.TempDir(), "landmarks.json")
+	assert.NoError(t, loader.ExportToJSON(&Landmark{}, file, "name = ?", "tower"))
+
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":1,"name":"tower","location":{"x":2.2945,"y":48.8584}}`+"\n", string(content))
+
+	assert.NoError(t, db.Exec("DELETE FROM landmarks").Error)
+	assert.NoError(t, loader.ImportJSON(&Landmark{}, file, duckdb.JSONOptions{}))
+
+	arrayFile := filepath.Join(t.TempDir(), "it's array.json")
+	assert.NoError(t, os.WriteFile(arrayFile, []byte(`[{"id":7,"name":"arch","location":{"x":1.5,"y":-2.5}}]`), 0o600))
+	assert.NoError(t, loader.ImportJSON(&Landmark{}, arrayFile, duckdb.JSONOptions{Format: duckdb.JSONFormatArray}))
+
+	var landmarks []Landmark
+	assert.NoError(t, db.Raw("SELECT * FROM landmarks ORDER BY id").Scan(&landmarks).Error)
+	assert.Equal(t, []Landmark{
+		{ID: 1, Name: "tower", Location: GeoPoint{X: 2.2945, Y: 48.8584}},
+		{ID: 7, Name: "arch", Location: GeoPoint{X: 1.5, Y: -2.5}},
+	}, landmarks)
+}
@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type cacheEntry struct {
+	exists    bool
+	expiresAt time.Time
+}
+
+// CachedMigrator caches the information_schema lookups of HasTable, HasColumn
+// and HasIndex for ttl. A non-positive ttl keeps entries until InvalidateCache.
+// DDL issued through the CachedMigrator invalidates the cache automatically.
+type CachedMigrator struct {
+	Migrator
+	ttl   time.Duration
+	cache *sync.Map
+}
+
+// NewCachedMigrator wraps the migrator of db, which must be opened with this dialector.
+func NewCachedMigrator(db *gorm.DB, ttl time.Duration) *CachedMigrator {
+	return &CachedMigrator{Migrator: db.Migrator().(Migrator), ttl: ttl, cache: &sync.Map{}}
+}
+
+// InvalidateCache drops all cached metadata.
+func (m *CachedMigrator) InvalidateCache() {
+	m.cache.Range(func(key, _ interface{}) bool {
+		m.cache.Delete(key)
+		return true
+	})
+}
+
+func (m *CachedMigrator) cached(key string, lookup func() bool) bool {
+	if v, ok := m.cache.Load(key); ok {
+		entry := v.(cacheEntry)
+		if m.ttl <= 0 || time.Now().Before(entry.expiresAt) {
+			return entry.exists
+		}
+	}
+
+	exists := lookup()
+	m.cache.Store(key, cacheEntry{exists: exists, expiresAt: time.Now().Add(m.ttl)})
+	return exists
+}
+
+func (m *CachedMigrator) tableKey(value interface{}) string {
+	var table string
+	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		table = stmt.Table
+		return nil
+	})
+	return table
+}
+
+func (m *CachedMigrator) HasTable(value interface{}) bool {
+	return m.cached("table:"+m.tableKey(value), func() bool {
+		return m.Migrator.HasTable(value)
+	})
+}
+
+func (m *CachedMigrator) HasColumn(value interface{}, field string) bool {
+	return m.cached("column:"+m.tableKey(value)+"."+field, func() bool {
+		return m.Migrator.HasColumn(value, field)
+	})
+}
+
+func (m *CachedMigrator) HasIndex(value interface{}, name string) bool {
+	return m.cached("index:"+m.tableKey(value)+"."+name, func() bool {
+		return m.Migrator.HasIndex(value, name)
+	})
+}
+
+// DDL
+
+func (m *CachedMigrator) AutoMigrate(values ...interface{}) error {
+	defer m.InvalidateCache()
+	return m.Migrator.AutoMigrate(values...)
+}
+
+func (m *CachedMigrator) CreateTable(values ...interface{}) error {
+	defer m.InvalidateCache()
+	return m.Migrator.CreateTable(values...)
+}
+
+func (m *CachedMigrator) DropTable(values ...interface{}) error {
+	defer m.InvalidateCache()
+	return m.Migrator.DropTable(values...)
+}
+
+func (m *CachedMigrator) RenameTable(oldName, newName interface{}) error {
+	defer m.InvalidateCache()
+	return m.Migrator.RenameTable(oldName, newName)
+}
+
+func (m *CachedMigrator) AddColumn(value interface{}, field string) error {
+	defer m.InvalidateCache()
+	return m.Migrator.AddColumn(value, field)
+}
+
+func (m *CachedMigrator) DropColumn(value interface{}, field string) error {
+	defer m.InvalidateCache()
+	return m.Migrator.DropColumn(value, field)
+}
+
+func (m *CachedMigrator) RenameColumn(value interface{}, oldName, field string) error {
+	defer m.InvalidateCache()
+	return m.Migrator.RenameColumn(value, oldName, field)
+}
+
+func (m *CachedMigrator) CreateIndex(value interface{}, name string) error {
+	defer m.InvalidateCache()
+	return m.Migrator.CreateIndex(value, name)
+}
+
+func (m *CachedMigrator) DropIndex(value interface{}, name string) error {
+	defer m.InvalidateCache()
+	return m.Migrator.DropIndex(value, name)
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestCachedMigratorHit verifies that repeated lookups are served from the cache.
+func TestCachedMigratorHit(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	var queries int
+	err := db.Callback().Row().Before("gorm:row").Register("test:count_rows", func(*gorm.DB) {
+		queries++
+	})
+	assert.NoError(t, err)
+
+	m := duckdb.NewCachedMigrator(db, time.Minute)
+	assert.NoError(t, m.CreateTable(&Product{}))
+
+	queries = 0
+	assert.True(t, m.HasTable(&Product{}))
+	assert.True(t, m.HasColumn(&Product{}, "price"))
+	assert.Equal(t, 2, queries)
+
+	assert.True(t, m.HasTable(&Product{}))
+	assert.True(t, m.HasColumn(&Product{}, "price"))
+	assert.Equal(t, 2, queries, "cached lookups should not query the database")
+
+	// drop behind the cache's back, a cached lookup still reports the table
+	assert.NoError(t, db.Exec("DROP TABLE products").Error)
+	assert.True(t, m.HasTable(&Product{}))
+
+	m.InvalidateCache()
+	assert.False(t, m.HasTable(&Product{}))
+}
+
+// TestCachedMigratorInvalidation verifies that DDL through the cached migrator clears the cache.
+func TestCachedMigratorInvalidation(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	m := duckdb.NewCachedMigrator(db, time.Minute)
+	assert.False(t, m.HasTable(&Product{}))
+
+	assert.NoError(t, m.CreateTable(&Product{}))
+	assert.True(t, m.HasTable(&Product{}))
+
+	assert.NoError(t, m.DropTable(&Product{}))
+	assert.False(t, m.HasTable(&Product{}))
+}
+
+// TestCachedMigratorTTL verifies that entries expire after the ttl.
+func TestCachedMigratorTTL(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	m := duckdb.NewCachedMigrator(db, 50*time.Millisecond)
+	assert.False(t, m.HasTable(&Product{}))
+
+	assert.NoError(t, db.Migrator().CreateTable(&Product{}))
+	assert.False(t, m.HasTable(&Product{}))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, m.HasTable(&Product{}))
+}
@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TypeOf emits TYPEOF(col), the DuckDB type name of col's value.
+func TypeOf(col string) clause.Expr {
+	return clause.Expr{SQL: "TYPEOF(?)", Vars: []interface{}{clause.Column{Name: col}}}
+}
+
+// ResolveType returns the runtime type of colName by reading TYPEOF from the first row of tableName,
+// it returns sql.ErrNoRows for an empty table.
+func ResolveType(db *gorm.DB, tableName, colName string) (typeName string, err error) {
+	err = db.Raw("SELECT ? FROM ? LIMIT 1", TypeOf(colName), clause.Table{Name: tableName}).Row().Scan(&typeName)
+	return
+}
+
+// RegexFullMatch emits regexp_full_match(col, pattern), true when pattern matches the whole value.
+func RegexFullMatch(col, pattern string) clause.Expr {
+	return clause.Expr{SQL: "regexp_full_match(?, ?)", Vars: []interface{}{clause.Column{Name: col}, pattern}}
+}
+
+// RegexSearch emits regexp_matches(col, pattern), true when pattern matches anywhere in the value.
+func RegexSearch(col, pattern string) clause.Expr {
+	return clause.Expr{SQL: "regexp_matches(?, ?)", Vars: []interface{}{clause.Column{Name: col}, pattern}}
+}
+
+// AnyValue emits any_value(col), the first non-NULL value of col in each group.
+func AnyValue(col string) clause.Expr {
+	return clause.Expr{SQL: "any_value(?)", Vars: []interface{}{clause.Column{Name: col}}}
+}
+
+// BoolAnd emits bool_and(col), true when every value of col in each group is true.
+func BoolAnd(col string) clause.Expr {
+	return clause.Expr{SQL: "bool_and(?)", Vars: []interface{}{clause.Column{Name: col}}}
+}
+
+// BoolOr emits bool_or(col), true when any value of col in each group is true.
+func BoolOr(col string) clause.Expr {
+	return clause.Expr{SQL: "bool_or(?)", Vars: []interface{}{clause.Column{Name: col}}}
+}
+
+// StringAgg emits string_agg(col, sep ORDER BY orderBy...), the non-NULL values of col
+// in each group joined by sep. orderBy entries are written as raw SQL, e.g. "name DESC".
+func StringAgg(col, sep string, orderBy ...string) clause.Expr {
+	sql := "string_agg(?, ?"
+	if len(orderBy) > 0 {
+		sql += " ORDER BY " + strings.Join(orderBy, ", ")
+	}
+	return clause.Expr{SQL: sql + ")", Vars: []interface{}{clause.Column{Name: col}, stringLiteral(sep)}}
+}
+
+// ArraySlice emits array_slice(col, start, stop), the elements of the list col from start to stop, 1-based and inclusive.
+func ArraySlice(col string, start, stop int) clause.Expr {
+	return clause.Expr{SQL: "array_slice(?, ?, ?)", Vars: []interface{}{clause.Column{Name: col}, start, stop}}
+}
+
+// ArrayLength emits array_length(col), the number of elements of the list col.
+func ArrayLength(col string) clause.Expr {
+	return clause.Expr{SQL: "array_length(?)", Vars: []interface{}{clause.Column{Name: col}}}
+}
+
+// ArrayPosition emits array_position(col, elem), the 1-based index of elem in the list col, NULL if absent.
+func ArrayPosition(col, elem string) clause.Expr {
+	return clause.Expr{SQL: "array_position(?, ?)", Vars: []interface{}{clause.Column{Name: col}, elem}}
+}
+
+// InTimezone emits col AT TIME ZONE 'tz'. For a TIMESTAMPTZ col it is the local time in tz,
+// for a TIMESTAMP col it is the TIMESTAMPTZ of that local time in tz.
+func InTimezone(col, tz string) clause.Expr {
+	return clause.Expr{SQL: "? AT TIME ZONE ?", Vars: []interface{}{clause.Column{Name: col}, stringLiteral(tz)}}
+}
+
+// ConvertTimezone emits (col AT TIME ZONE 'fromTZ') AT TIME ZONE 'toTZ', converting
+// the TIMESTAMP col from a local time in fromTZ to the same instant's local time in toTZ.
+func ConvertTimezone(col, fromTZ, toTZ string) clause.Expr {
+	return clause.Expr{SQL: "(?) AT TIME ZONE ?", Vars: []interface{}{InTimezone(col, fromTZ), stringLiteral(toTZ)}}
+}
+
+// NaturalOrder emits a sort key for col that orders embedded numbers by value,
+// so item2 sorts before item10:
+//
+//	db.Order(clause.OrderBy{Expression: duckdb.NaturalOrder("name")})
+//
+// DuckDB has no natural collation, so this is a SQL expression workaround: every run of digits
+// is left-padded with zeros to 20 digits by two regexp_replace calls. Longer numbers keep their
+// digits and only sort correctly among numbers of the same length.
+func NaturalOrder(col string) clause.Expr {
+	return clause.Expr{
+		SQL:  `regexp_replace(regexp_replace(?, '(\d+)', '0000000000000000000\1', 'g'), '0*(\d{20})', '\1', 'g')`,
+		Vars: []interface{}{clause.Column{Name: col}},
+	}
+}
+
+// Hash emits hash(col), a deterministic UBIGINT hash of col's value.
+func Hash(col string) clause.Expr {
+	return clause.Expr{SQL: "hash(?)", Vars: []interface{}{clause.Column{Name: col}}}
+}
+
+// MD5 emits md5(col), the hex MD5 digest of col's value.
+func MD5(col string) clause.Expr {
+	return clause.Expr{SQL: "md5(?)", Vars: []interface{}{clause.Column{Name: col}}}
+}
+
+// RowHash emits hash(col1, col2, ...), a single fingerprint of all cols of a row.
+func RowHash(cols ...string) clause.Expr {
+	placeholders := make([]string, len(cols))
+	vars := make([]interface{}, len(cols))
+	for i, col := range cols {
+		placeholders[i] = "?"
+		vars[i] = clause.Column{Name: col}
+	}
+	return clause.Expr{SQL: "hash(" + strings.Join(placeholders, ", ") + ")", Vars: vars}
+}
+
+// If emits IF(condition, trueVal, falseVal), DuckDB's shorthand for CASE WHEN condition THEN trueVal ELSE falseVal END.
+// A string condition is written as raw SQL, any other condition and the values are added as vars,
+// so pass clause.Column or clause.Expr to reference columns or expressions:
+//
+//	duckdb.If("price > 10", "expensive", clause.Column{Name: "category"})
+func If(condition, trueVal, falseVal interface{}) clause.Expr {
+	if sql, ok := condition.(string); ok {
+		condition = clause.Expr{SQL: sql}
+	}
+	return clause.Expr{SQL: "IF(?, ?, ?)", Vars: []interface{}{condition, trueVal, falseVal}}
+}
+
+// NullIf emits NULLIF(val, nullVal), NULL when val equals nullVal and val otherwise.
+func NullIf(val, nullVal interface{}) clause.Expr {
+	return clause.Expr{SQL: "NULLIF(?, ?)", Vars: []interface{}{val, nullVal}}
+}
+
+// IfNull emits IFNULL(val, default_), default_ when val is NULL and val otherwise.
+func IfNull(val, default_ interface{}) clause.Expr {
+	return clause.Expr{SQL: "IFNULL(?, ?)", Vars: []interface{}{val, default_}}
+}
+
+// ExcludedColumn emits EXCLUDED.col, the value col would have had in the row rejected by ON CONFLICT,
+// for DO UPDATE expressions beyond clause.AssignmentColumns:
+//
+//	clause.OnConflict{
+//		Columns: []clause.Column{{Name: "sku"}},
+//		DoUpdates: clause.Assignments(map[string]interface{}{
+//			"price": clause.Expr{SQL: "GREATEST(?, items.price)", Vars: []interface{}{duckdb.ExcludedColumn("price")}},
+//		}),
+//	}
+func ExcludedColumn(col string) clause.Expr {
+	return clause.Expr{SQL: "?", Vars: []interface{}{clause.Column{Table: "EXCLUDED", Name: col}}}
+}
+
+// StructField is a named value of StructPack.
+type StructField struct {
+	Name  string
+	Value interface{}
+}
+
+// StructPack emits struct_pack("name" := value, ...), a STRUCT with fields in the given order.
+func StructPack(fields ...StructField) clause.Expr {
+	parts := make([]string, len(fields))
+	vars := make([]interface{}, len(fields))
+	for i, field := range fields {
+		parts[i] = quoteIdentifier(field.Name) + " := ?"
+		vars[i] = field.Value
+	}
+	return clause.Expr{SQL: "struct_pack(" + strings.Join(parts, ", ") + ")", Vars: vars}
+}
+
+// StructExtract emits struct_extract(col, 'field'), the field of the STRUCT col.
+func StructExtract(col, field string) clause.Expr {
+	return clause.Expr{SQL: "struct_extract(?, ?)", Vars: []interface{}{clause.Column{Name: col}, stringLiteral(field)}}
+}
+
+// ArrayContains emits list_contains(col, val), true when the list col holds val.
+func ArrayContains(col string, val int64) clause.Expr {
+	return clause.Expr{SQL: "list_contains(?, ?)", Vars: []interface{}{clause.Column{Name: col}, val}}
+}
+
+// ArrayOverlap emits list_has_any(col, vals), true when the list col shares an element with vals.
+func ArrayOverlap(col string, vals []int64) clause.Expr {
+	return clause.Expr{SQL: "list_has_any(?, ?::BIGINT[])", Vars: []interface{}{clause.Column{Name: col}, int64List(vals)}}
+}
+
+// ArrayAppend emits list_append(col, val), for updates like
+//
+//	db.Model(&post).Update("tag_ids", duckdb.ArrayAppend("tag_ids", 5))
+func ArrayAppend(col string, val int64) clause.Expr {
+	return clause.Expr{SQL: "list_append(?, ?)", Vars: []interface{}{clause.Column{Name: col}, val}}
+}
+
+// ArrayRemove emits list_filter(col, x -> x <> val), the list col without any occurrence of val.
+func ArrayRemove(col string, val int64) clause.Expr {
+	return clause.Expr{SQL: "list_filter(?, x -> x <> " + strconv.FormatInt(val, 10) + ")", Vars: []interface{}{clause.Column{Name: col}}}
+}
+
+// RegexSplitToTable emits a subquery with one row per part of stringExpr split on the regular
+// expression pattern, in a column named value, for use as the table of a query.
+// stringExpr is written as raw SQL with vars bound to its placeholders:
+//
+//	db.Table("?", duckdb.RegexSplitToTable("?", ",\\s*", "a, b,c")).Pluck("value", &parts)
+//
+// DuckDB has no regexp_split_to_table function, so the list of string_split_regex is unnested.
+func RegexSplitToTable(stringExpr, pattern string, vars ...interface{}) clause.Expr {
+	return clause.Expr{
+		SQL:  "(SELECT unnest(string_split_regex(" + stringExpr + ", ?)) AS value)",
+		Vars: append(append([]interface{}{}, vars...), pattern),
+	}
+}
+
+// JSONObject emits json_object(key1, value1, ...), a JSON object built from alternating keys
+// and values. Keys and values are added as vars, so pass clause.Column to reference columns:
+//
+//	duckdb.JSONObject("name", clause.Column{Name: "name"}, "qty", clause.Column{Name: "qty"})
+func JSONObject(pairs ...interface{}) clause.Expr {
+	placeholders := make([]string, len(pairs))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	return clause.Expr{SQL: "json_object(" + strings.Join(placeholders, ", ") + ")", Vars: pairs}
+}
+
+// JSONArray emits json_array(value1, ...), a JSON array of values, added as vars like in JSONObject.
+func JSONArray(values ...interface{}) clause.Expr {
+	placeholders := make([]string, len(values))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	return clause.Expr{SQL: "json_array(" + strings.Join(placeholders, ", ") + ")", Vars: values}
+}
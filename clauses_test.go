@@ -0,0 +1,481 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TypedRow struct {
+	Flag    bool      `gorm:"column:flag"`
+	Small   int16     `gorm:"column:small"`
+	Medium  int32     `gorm:"column:medium"`
+	Large   int64     `gorm:"column:large"`
+	Amount  float64   `gorm:"column:amount"`
+	Label   string    `gorm:"column:label"`
+	Stamp   time.Time `gorm:"column:stamp"`
+	Payload []byte    `gorm:"column:payload"`
+}
+
+// TestTypeOf verifies TYPEOF reports the DuckDB type DataTypeOf chose for each Go type.
+func TestTypeOf(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&TypedRow{}))
+
+	_, err := duckdb.ResolveType(db, "typed_rows", "flag")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	assert.NoError(t, db.Create(&TypedRow{
+		Flag: true, Small: 1, Medium: 2, Large: 3, Amount: 4.5, Label: "label", Stamp: time.Now(), Payload: []byte("payload"),
+	}).Error)
+
+	expected := map[string]string{
+		"flag":    "BOOLEAN",
+		"small":   "SMALLINT",
+		"medium":  "INTEGER",
+		"large":   "BIGINT",
+		"amount":  "DECIMAL(18,3)",
+		"label":   "VARCHAR",
+		"stamp":   "TIMESTAMP WITH TIME ZONE",
+		"payload": "BLOB",
+	}
+	for col, typeName := range expected {
+		var selected string
+		assert.NoError(t, db.Model(&TypedRow{}).Select("?", duckdb.TypeOf(col)).Limit(1).Scan(&selected).Error)
+		assert.Equal(t, typeName, selected, col)
+
+		resolved, err := duckdb.ResolveType(db, "typed_rows", col)
+		assert.NoError(t, err)
+		assert.Equal(t, typeName, resolved, col)
+	}
+}
+
+// TestRegexMatch verifies full matches and searches return the correct subsets.
+func TestRegexMatch(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE fruits (name VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO fruits VALUES ('apple'), ('pineapple'), ('apple pie'), ('banana')").Error)
+
+	var names []string
+	assert.NoError(t, db.Table("fruits").Where(duckdb.RegexFullMatch("name", "apple")).Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"apple"}, names)
+
+	names = nil
+	assert.NoError(t, db.Table("fruits").Where(duckdb.RegexSearch("name", "apple")).Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"apple", "apple pie", "pineapple"}, names)
+
+	names = nil
+	assert.NoError(t, db.Table("fruits").Where(duckdb.RegexFullMatch("name", "a.*e")).Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"apple", "apple pie"}, names)
+}
+
+// TestBoolAggregates verifies bool_and, bool_or and any_value per group.
+func TestBoolAggregates(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE checks (grp VARCHAR, ok BOOLEAN)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO checks VALUES ('all', true), ('all', true), ('some', true), ('some', false), ('none', false), ('none', false)").Error)
+
+	var rows []struct {
+		Grp   string
+		AllOk bool
+		AnyOk bool
+		Ok    bool
+	}
+	err := db.Table("checks").
+		Select("grp, ? AS all_ok, ? AS any_ok, ? AS ok", duckdb.BoolAnd("ok"), duckdb.BoolOr("ok"), duckdb.AnyValue("ok")).
+		Group("grp").Order("grp").Find(&rows).Error
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+
+	assert.Equal(t, "all", rows[0].Grp)
+	assert.True(t, rows[0].AllOk)
+	assert.True(t, rows[0].AnyOk)
+	assert.True(t, rows[0].Ok)
+
+	assert.Equal(t, "none", rows[1].Grp)
+	assert.False(t, rows[1].AllOk)
+	assert.False(t, rows[1].AnyOk)
+	assert.False(t, rows[1].Ok)
+
+	assert.Equal(t, "some", rows[2].Grp)
+	assert.False(t, rows[2].AllOk)
+	assert.True(t, rows[2].AnyOk)
+}
+
+// TestStringAgg verifies names are joined per category in order, skipping NULLs.
+func TestStringAgg(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE catalog_items (category VARCHAR, name VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO catalog_items VALUES ('fruit', 'pear'), ('fruit', 'apple'), ('fruit', NULL), ('fruit', 'fig'), ('tool', 'saw')").Error)
+
+	var rows []struct {
+		Category string
+		Names    string
+	}
+	err := db.Table("catalog_items").
+		Select("category, ? AS names", duckdb.StringAgg("name", ", ", "name")).
+		Group("category").Order("category").Find(&rows).Error
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "apple, fig, pear", rows[0].Names)
+	assert.Equal(t, "saw", rows[1].Names)
+
+	var names string
+	assert.NoError(t, db.Table("catalog_items").Where("category = ?", "fruit").
+		Select("?", duckdb.StringAgg("name", "|", "name DESC")).Row().Scan(&names))
+	assert.Equal(t, "pear|fig|apple", names)
+}
+
+// TestArrayFunctions verifies filtering on array_length and selecting array_slice and array_position.
+func TestArrayFunctions(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE baskets (id INTEGER, items VARCHAR[])").Error)
+	assert.NoError(t, db.Exec("INSERT INTO baskets VALUES (1, ['apple']), (2, ['apple', 'pear', 'plum']), (3, ['fig', 'kiwi', 'lime', 'plum'])").Error)
+
+	var ids []int
+	assert.NoError(t, db.Table("baskets").Where("? >= ?", duckdb.ArrayLength("items"), 3).Order("id").Pluck("id", &ids).Error)
+	assert.Equal(t, []int{2, 3}, ids)
+
+	var rows []struct {
+		ID       int
+		Head     string
+		Position *int
+	}
+	err := db.Table("baskets").
+		Select("id, array_to_string(?, ',') AS head, ? AS position", duckdb.ArraySlice("items", 1, 2), duckdb.ArrayPosition("items", "plum")).
+		Order("id").Find(&rows).Error
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "apple", rows[0].Head)
+	assert.Nil(t, rows[0].Position)
+	assert.Equal(t, "apple,pear", rows[1].Head)
+	assert.Equal(t, 3, *rows[1].Position)
+	assert.Equal(t, "fig,kiwi", rows[2].Head)
+	assert.Equal(t, 4, *rows[2].Position)
+}
+
+// TestConvertTimezone verifies a local EST time converts to the same instant's PST time.
+func TestConvertTimezone(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE meetings (starts_at TIMESTAMP)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO meetings VALUES ('2024-03-01 09:00:00')").Error)
+
+	var startsAt time.Time
+	assert.NoError(t, db.Table("meetings").Select("?", duckdb.ConvertTimezone("starts_at", "America/New_York", "America/Los_Angeles")).Scan(&startsAt).Error)
+	assert.Equal(t, 6, startsAt.Hour())
+}
+
+// TestNaturalOrder verifies embedded numbers sort by value instead of lexically.
+func TestNaturalOrder(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE items (name VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO items VALUES ('item2'), ('item10'), ('item1')").Error)
+
+	var names []string
+	assert.NoError(t, db.Table("items").Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"item1", "item10", "item2"}, names)
+
+	assert.NoError(t, db.Table("items").Order(clause.OrderBy{Expression: duckdb.NaturalOrder("name")}).Pluck("name", &names).Error)
+	assert.Equal(t, []string{"item1", "item2", "item10"}, names)
+}
+
+// TestRowHash verifies the row hash changes whenever any hashed column changes.
+func TestRowHash(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Product{}))
+	product := Product{Name: "pen", Price: 1.5}
+	assert.NoError(t, db.Create(&product).Error)
+
+	fingerprint := func() (row uint64, name uint64, digest string) {
+		var result struct {
+			RowHash  uint64
+			NameHash uint64
+			Digest   string
+		}
+		assert.NoError(t, db.Model(&Product{}).
+			Select("? AS row_hash, ? AS name_hash, ? AS digest", duckdb.RowHash("name", "price"), duckdb.Hash("name"), duckdb.MD5("name")).
+			Where("id = ?", product.ID).Scan(&result).Error)
+		return result.RowHash, result.NameHash, result.Digest
+	}
+
+	row1, name1, digest1 := fingerprint()
+	assert.Equal(t, "03f00e8e9d0d0847bb10a3a22334274a", digest1)
+
+	row2, name2, digest2 := fingerprint()
+	assert.Equal(t, row1, row2)
+	assert.Equal(t, name1, name2)
+	assert.Equal(t, digest1, digest2)
+
+	assert.NoError(t, db.Model(&product).Update("price", 2.5).Error)
+	row3, name3, _ := fingerprint()
+	assert.NotEqual(t, row1, row3)
+	assert.Equal(t, name1, name3)
+
+	assert.NoError(t, db.Model(&product).Update("name", "ink").Error)
+	row4, name4, digest4 := fingerprint()
+	assert.NotEqual(t, row3, row4)
+	assert.NotEqual(t, name1, name4)
+	assert.NotEqual(t, digest1, digest4)
+}
+
+// TestConditionalFunctions verifies IF, NULLIF and IFNULL in a SELECT.
+func TestConditionalFunctions(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE stock (name VARCHAR, qty INTEGER, note VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO stock VALUES ('bolt', 0, NULL), ('nut', 5, 'boxed')").Error)
+
+	var rows []struct {
+		Name      string
+		Available string
+		Qty       *int
+		Note      string
+	}
+	qty := clause.Column{Name: "qty"}
+	err := db.Table("stock").
+		Select("name, ? AS available, ? AS qty, ? AS note",
+			duckdb.If("qty > 0", "yes", "no"), duckdb.NullIf(qty, 0), duckdb.IfNull(clause.Column{Name: "note"}, "none")).
+		Order("name").Find(&rows).Error
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, "no", rows[0].Available)
+	assert.Nil(t, rows[0].Qty)
+	assert.Equal(t, "none", rows[0].Note)
+
+	assert.Equal(t, "yes", rows[1].Available)
+	assert.Equal(t, 5, *rows[1].Qty)
+	assert.Equal(t, "boxed", rows[1].Note)
+}
+
+type UpsertItem struct {
+	ID    uint    `gorm:"column:id;primaryKey;autoIncrement"`
+	Sku   string  `gorm:"column:sku;unique"`
+	Price float64 `gorm:"column:price"`
+}
+
+// TestExcludedColumn verifies EXCLUDED references in ON CONFLICT DO UPDATE, both from AssignmentColumns and ExcludedColumn.
+func TestExcludedColumn(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&UpsertItem{}))
+	assert.NoError(t, db.Create(&UpsertItem{Sku: "a", Price: 10}).Error)
+
+	keepHigher := clause.OnConflict{
+		Columns: []clause.Column{{Name: "sku"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"price": clause.Expr{SQL: "GREATEST(?, ?)", Vars: []interface{}{duckdb.ExcludedColumn("price"), clause.Column{Table: "upsert_items", Name: "price"}}},
+		}),
+	}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(keepHigher).Create(&UpsertItem{Sku: "a", Price: 5})
+	})
+	assert.Contains(t, sql, "ON CONFLICT (sku) DO UPDATE SET price=GREATEST(EXCLUDED.price, upsert_items.price)")
+
+	price := func() float64 {
+		var item UpsertItem
+		assert.NoError(t, db.Where("sku = ?", "a").First(&item).Error)
+		return item.Price
+	}
+
+	assert.NoError(t, db.Clauses(keepHigher).Create(&UpsertItem{Sku: "a", Price: 5}).Error)
+	assert.Equal(t, 10.0, price())
+	assert.NoError(t, db.Clauses(keepHigher).Create(&UpsertItem{Sku: "a", Price: 20}).Error)
+	assert.Equal(t, 20.0, price())
+
+	overwrite := clause.OnConflict{Columns: []clause.Column{{Name: "sku"}}, DoUpdates: clause.AssignmentColumns([]string{"price"})}
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(overwrite).Create(&UpsertItem{Sku: "a", Price: 5})
+	})
+	assert.Contains(t, sql, "DO UPDATE SET price=excluded.price")
+
+	assert.NoError(t, db.Clauses(overwrite).Create(&UpsertItem{Sku: "a", Price: 5}).Error)
+	assert.Equal(t, 5.0, price())
+
+	var count int64
+	assert.NoError(t, db.Model(&UpsertItem{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+// TestReturning verifies RETURNING fills the models of inserts, updates and deletes in the same statement.
+func TestReturning(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&UpsertItem{}))
+
+	items := []UpsertItem{{Sku: "a", Price: 1}, {Sku: "b", Price: 2}, {Sku: "c", Price: 3}}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Create(&items)
+	})
+	assert.Contains(t, sql, "RETURNING id")
+	assert.NoError(t, db.Create(&items).Error)
+	assert.Equal(t, []uint{1, 2, 3}, []uint{items[0].ID, items[1].ID, items[2].ID})
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&[]UpsertItem{}).Clauses(clause.Returning{}).Where("price > ?", 1).Update("price", gorm.Expr("price * 10"))
+	})
+	assert.Contains(t, sql, "RETURNING *")
+
+	var updated []UpsertItem
+	assert.NoError(t, db.Model(&updated).Clauses(clause.Returning{}).Where("price > ?", 1).Update("price", gorm.Expr("price * 10")).Error)
+	assert.Len(t, updated, 2)
+	for _, item := range updated {
+		assert.NotEmpty(t, item.Sku)
+		assert.GreaterOrEqual(t, item.Price, 20.0)
+	}
+
+	var deleted []UpsertItem
+	assert.NoError(t, db.Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "sku"}}}).Where("sku = ?", "a").Delete(&deleted).Error)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, uint(1), deleted[0].ID)
+	assert.Equal(t, "a", deleted[0].Sku)
+	assert.Zero(t, deleted[0].Price)
+}
+
+// TestStructPackExtract verifies a packed STRUCT is selected, stored and its fields extracted.
+func TestStructPackExtract(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	pack := duckdb.StructPack(
+		duckdb.StructField{Name: "name", Value: "alice"},
+		duckdb.StructField{Name: "age", Value: 30},
+	)
+
+	var packed interface{}
+	assert.NoError(t, db.Raw("SELECT ?", pack).Row().Scan(&packed))
+	assert.Equal(t, map[string]interface{}{"name": "alice", "age": int64(30)}, packed)
+
+	assert.NoError(t, db.Exec("CREATE TABLE members (id INTEGER, info STRUCT(name VARCHAR, age INTEGER))").Error)
+	assert.NoError(t, db.Exec("INSERT INTO members SELECT 1, ?", pack).Error)
+	assert.NoError(t, db.Exec("INSERT INTO members SELECT 2, ?", duckdb.StructPack(
+		duckdb.StructField{Name: "name", Value: "bob"},
+		duckdb.StructField{Name: "age", Value: 41},
+	)).Error)
+
+	var rows []struct {
+		Name string
+		Age  int
+	}
+	err := db.Table("members").
+		Select("? AS name, ? AS age", duckdb.StructExtract("info", "name"), duckdb.StructExtract("info", "age")).
+		Where("? > ?", duckdb.StructExtract("info", "age"), 35).Find(&rows).Error
+	assert.NoError(t, err)
+	if !assert.Len(t, rows, 1) {
+		return
+	}
+	assert.Equal(t, "bob", rows[0].Name)
+	assert.Equal(t, 41, rows[0].Age)
+
+	assert.NoError(t, db.Raw("SELECT ?", duckdb.StructPack(duckdb.StructField{Name: "a := 1, b", Value: 2})).Row().Scan(&packed))
+	assert.Equal(t, map[string]interface{}{"a := 1, b": int64(2)}, packed)
+}
+
+// TestArrayElementFunctions verifies filtering BIGINT[] columns by contains and overlap, and updating by append and remove.
+func TestArrayElementFunctions(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE tagged (id INTEGER, tag_ids BIGINT[])").Error)
+	assert.NoError(t, db.Exec("INSERT INTO tagged VALUES (1, [1, 2]), (2, [2, 3]), (3, [4])").Error)
+
+	var ids []int
+	assert.NoError(t, db.Table("tagged").Where(duckdb.ArrayContains("tag_ids", 2)).Order("id").Pluck("id", &ids).Error)
+	assert.Equal(t, []int{1, 2}, ids)
+
+	assert.NoError(t, db.Table("tagged").Where(duckdb.ArrayOverlap("tag_ids", []int64{3, 4, 9})).Order("id").Pluck("id", &ids).Error)
+	assert.Equal(t, []int{2, 3}, ids)
+
+	assert.NoError(t, db.Table("tagged").Where("id = ?", 3).Update("tag_ids", duckdb.ArrayAppend("tag_ids", 2)).Error)
+	assert.NoError(t, db.Table("tagged").Where("id = ?", 1).Update("tag_ids", duckdb.ArrayRemove("tag_ids", 2)).Error)
+
+	assert.NoError(t, db.Table("tagged").Where(duckdb.ArrayContains("tag_ids", 2)).Order("id").Pluck("id", &ids).Error)
+	assert.Equal(t, []int{2, 3}, ids)
+
+	var tags string
+	assert.NoError(t, db.Raw("SELECT tag_ids::VARCHAR FROM tagged WHERE id = ?", 3).Row().Scan(&tags))
+	assert.Equal(t, "[4, 2]", tags)
+}
+
+// TestRegexSplitToTable verifies each part of a split string lands in its own row, matching a regexp split.
+func TestRegexSplitToTable(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	source := "alpha, beta,gamma,  delta"
+	var parts []string
+	assert.NoError(t, db.Table("?", duckdb.RegexSplitToTable("?", `,\s*`, source)).Pluck("value", &parts).Error)
+	assert.Equal(t, regexp.MustCompile(`,\s*`).Split(source, -1), parts)
+
+	assert.NoError(t, db.Exec("CREATE TABLE csv_lines (line VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO csv_lines VALUES ('x;y;z')").Error)
+	assert.NoError(t, db.Table("?", duckdb.RegexSplitToTable("(SELECT line FROM csv_lines)", ";")).Pluck("value", &parts).Error)
+	assert.Equal(t, []string{"x", "y", "z"}, parts)
+}
+
+// TestJSONConstructors verifies JSON objects and arrays built from columns parse to the expected values.
+func TestJSONConstructors(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE json_items (name VARCHAR, qty INTEGER, price DOUBLE)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO json_items VALUES ('bolt', 3, 0.25)").Error)
+
+	var object, array string
+	err := db.Table("json_items").Select("?::VARCHAR, ?::VARCHAR",
+		duckdb.JSONObject("name", clause.Column{Name: "name"}, "qty", clause.Column{Name: "qty"}, "tags", duckdb.JSONArray("a", "b")),
+		duckdb.JSONArray(clause.Column{Name: "name"}, clause.Column{Name: "price"}, nil),
+	).Row().Scan(&object, &array)
+	assert.NoError(t, err)
+
+	var parsedObject map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(object), &parsedObject))
+	assert.Equal(t, map[string]interface{}{"name": "bolt", "qty": float64(3), "tags": []interface{}{"a", "b"}}, parsedObject)
+
+	var parsedArray []interface{}
+	assert.NoError(t, json.Unmarshal([]byte(array), &parsedArray))
+	assert.Equal(t, []interface{}{"bolt", 0.25, nil}, parsedArray)
+}
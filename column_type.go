@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+)
+
+// DuckDBColumnType is implemented by the column types returned by Migrator.ColumnTypes,
+// adding the storage details DuckDB reports for the column's segments.
+type DuckDBColumnType interface {
+	gorm.ColumnType
+	// StorageSize returns the bytes of the storage blocks holding the checkpointed segments of the column.
+	StorageSize() int64
+	// CompressionType returns the compression of the column's data segments, comma separated when they differ.
+	CompressionType() string
+	// HasStatistics reports whether any segment of the column carries statistics.
+	HasStatistics() bool
+}
+
+// baseColumnType is embedded under another name, as a field named ColumnType
+// would hide the ColumnType method of gorm.ColumnType.
+type baseColumnType = migrator.ColumnType
+
+type columnType struct {
+	baseColumnType
+	storageSize     int64
+	compressionType string
+	hasStatistics   bool
+}
+
+// Length only reports the length read from duckdb_columns(), the driver doesn't provide it.
+func (ct columnType) Length() (length int64, ok bool) {
+	return ct.LengthValue.Int64, ct.LengthValue.Valid
+}
+
+// DecimalSize only reports the size read from duckdb_columns(), the driver doesn't provide it.
+func (ct columnType) DecimalSize() (precision int64, scale int64, ok bool) {
+	return ct.DecimalSizeValue.Int64, ct.ScaleValue.Int64, ct.DecimalSizeValue.Valid
+}
+
+func (ct columnType) StorageSize() int64 {
+	return ct.storageSize
+}
+
+func (ct columnType) CompressionType() string {
+	return ct.compressionType
+}
+
+func (ct columnType) HasStatistics() bool {
+	return ct.hasStatistics
+}
@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var ErrReorderColumns = errors.New("new column order must list every column exactly once")
+
+// RenameColumnsMatching renames every column of value whose name matches pattern
+// to regexp.ReplaceAllString(name, replacement), and returns the original names of the renamed columns.
+func RenameColumnsMatching(db *gorm.DB, value interface{}, pattern, replacement string) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	m := db.Migrator()
+	columnTypes, err := m.ColumnTypes(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var renamed []string
+	for _, columnType := range columnTypes {
+		name := columnType.Name()
+		if !re.MatchString(name) {
+			continue
+		}
+
+		newName := re.ReplaceAllString(name, replacement)
+		if newName == name {
+			continue
+		}
+
+		if err := m.RenameColumn(value, name, newName); err != nil {
+			return renamed, err
+		}
+		renamed = append(renamed, name)
+	}
+	return renamed, nil
+}
+
+// ReorderColumns rebuilds value's table, a model or a table name, with its columns in newOrder,
+// which must list every column exactly once. The table is created again under a temporary name
+// with the same column types, NOT NULL, defaults and constraints, the rows are copied, the old
+// table is dropped, and the indexes are recreated, all in one transaction. Comments are not kept.
+func ReorderColumns(db *gorm.DB, value interface{}, newOrder []string) error {
+	table, err := tableNameOf(db, value)
+	if err != nil {
+		return err
+	}
+
+	var columns []struct {
+		ColumnName    string
+		DataType      string
+		IsNullable    bool
+		ColumnDefault *string
+	}
+	if err := db.Raw("SELECT column_name, data_type, is_nullable, column_default FROM duckdb_columns() "+
+		"WHERE database_name = current_database() AND schema_name = current_schema() AND table_name = ?", table).
+		Scan(&columns).Error; err != nil {
+		return err
+	}
+
+	definitions := make(map[string]string, len(columns))
+	for _, column := range columns {
+		definition := quoteIdentifier(column.ColumnName) + " " + column.DataType
+		if !column.IsNullable {
+			definition += " NOT NULL"
+		}
+		if column.ColumnDefault != nil {
+			definition += " DEFAULT " + *column.ColumnDefault
+		}
+		definitions[column.ColumnName] = definition
+	}
+
+	ordered := make([]string, 0, len(newOrder))
+	selects := make([]string, 0, len(newOrder))
+	for _, name := range newOrder {
+		definition, ok := definitions[name]
+		if !ok {
+			return fmt.Errorf("%w: unknown or repeated column %s", ErrReorderColumns, name)
+		}
+		delete(definitions, name)
+		ordered = append(ordered, definition)
+		selects = append(selects, quoteIdentifier(name))
+	}
+	if len(definitions) > 0 {
+		missing := make([]string, 0, len(definitions))
+		for name := range definitions {
+			missing = append(missing, name)
+		}
+		return fmt.Errorf("%w: missing %s", ErrReorderColumns, strings.Join(missing, ", "))
+	}
+
+	var constraints, indexes []string
+	if err := db.Raw("SELECT constraint_text FROM duckdb_constraints() WHERE database_name = current_database() "+
+		"AND schema_name = current_schema() AND table_name = ? AND constraint_type IN ('PRIMARY KEY', 'UNIQUE', 'CHECK', 'FOREIGN KEY') "+
+		"ORDER BY constraint_index", table).Scan(&constraints).Error; err != nil {
+		return err
+	}
+	if err := db.Raw("SELECT sql FROM duckdb_indexes() WHERE database_name = current_database() "+
+		"AND schema_name = current_schema() AND table_name = ? AND sql IS NOT NULL", table).Scan(&indexes).Error; err != nil {
+		return err
+	}
+
+	// names are quoted as they come from the catalog or the caller and are written into the statements
+	quotedTable, tmpTable := quoteIdentifier(table), quoteIdentifier(table+"_reorder")
+	err = db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			"CREATE TABLE " + tmpTable + " (" + strings.Join(append(ordered, constraints...), ", ") + ")",
+			"INSERT INTO " + tmpTable + " SELECT " + strings.Join(selects, ", ") + " FROM " + quotedTable,
+			"DROP TABLE " + quotedTable,
+			"ALTER TABLE " + tmpTable + " RENAME TO " + quotedTable,
+		}
+		for _, statement := range append(statements, indexes...) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if m, ok := db.Migrator().(Migrator); ok {
+		m.resetPreparedStmts()
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestRenameColumnsMatching verifies columns ending in _ts are renamed to end in _at.
+func TestRenameColumnsMatching(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE rename_rows (name VARCHAR, created_ts TIMESTAMP, updated_ts TIMESTAMP)").Error)
+
+	renamed, err := duckdb.RenameColumnsMatching(db, "rename_rows", `_ts$`, "_at")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"created_ts", "updated_ts"}, renamed)
+
+	m := db.Migrator()
+	assert.False(t, m.HasColumn("rename_rows", "created_ts"))
+	assert.False(t, m.HasColumn("rename_rows", "updated_ts"))
+	assert.True(t, m.HasColumn("rename_rows", "created_at"))
+	assert.True(t, m.HasColumn("rename_rows", "updated_at"))
+	assert.True(t, m.HasColumn("rename_rows", "name"))
+
+	_, err = duckdb.RenameColumnsMatching(db, "rename_rows", `(`, "")
+	assert.Error(t, err)
+}
+
+// TestReorderColumns verifies DESCRIBE lists the columns in the new order and rows, defaults and keys are kept.
+func TestReorderColumns(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE reorder_rows (id INTEGER PRIMARY KEY, name VARCHAR NOT NULL, price DOUBLE DEFAULT 1.5)").Error)
+	assert.NoError(t, db.Exec("CREATE INDEX idx_reorder_rows_name ON reorder_rows (name)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO reorder_rows (id, name) VALUES (1, 'pen'), (2, 'ink')").Error)
+
+	newOrder := []string{"price", "name", "id"}
+	assert.NoError(t, duckdb.ReorderColumns(db, "reorder_rows", newOrder))
+
+	var described []struct {
+		ColumnName string
+	}
+	assert.NoError(t, db.Raw("DESCRIBE reorder_rows").Scan(&described).Error)
+	var names []string
+	for _, column := range described {
+		names = append(names, column.ColumnName)
+	}
+	assert.Equal(t, newOrder, names)
+
+	var rows []struct {
+		ID    int
+		Name  string
+		Price float64
+	}
+	assert.NoError(t, db.Table("reorder_rows").Order("id").Find(&rows).Error)
+	if !assert.Len(t, rows, 2) {
+		return
+	}
+	assert.Equal(t, "pen", rows[0].Name)
+	assert.Equal(t, 1.5, rows[1].Price)
+
+	assert.Error(t, db.Exec("INSERT INTO reorder_rows (id, name) VALUES (1, 'dup')").Error)
+	assert.True(t, db.Migrator().HasIndex("reorder_rows", "idx_reorder_rows_name"))
+
+	assert.ErrorIs(t, duckdb.ReorderColumns(db, "reorder_rows", []string{"id", "name"}), duckdb.ErrReorderColumns)
+	assert.ErrorIs(t, duckdb.ReorderColumns(db, "reorder_rows", []string{"id", "name", "name"}), duckdb.ErrReorderColumns)
+	assert.ErrorIs(t, duckdb.ReorderColumns(db, "reorder_rows", []string{"id", "name", "cost"}), duckdb.ErrReorderColumns)
+
+	assert.NoError(t, db.Exec(`CREATE TABLE "price list" (id INTEGER, "unit price" DOUBLE)`).Error)
+	assert.NoError(t, duckdb.ReorderColumns(db, "price list", []string{"unit price", "id"}))
+	assert.NoError(t, db.Raw(`DESCRIBE "price list"`).Scan(&described).Error)
+	if assert.Len(t, described, 2) {
+		assert.Equal(t, "unit price", described[0].ColumnName)
+	}
+}
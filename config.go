@@ -0,0 +1,202 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownSetting = errors.New("unknown duckdb setting")
+	ErrInvalidConfig  = errors.New("invalid duckdb config")
+)
+
+// OpenWithConfig returns a dialector opening config.Path, or config.DSN when set, and applying
+// the settings and extensions of config:
+//
+//	duckdb.OpenWithConfig(duckdb.Config{Path: "analytics.db", ReadOnly: true, Threads: 4, MaxMemoryMB: 2048})
+func OpenWithConfig(config Config) gorm.Dialector {
+	return New(config)
+}
+
+// dsn returns DSN, or Path when DSN is empty, with access_mode=READ_ONLY added for ReadOnly.
+func (config *Config) dsn() string {
+	dsn := config.DSN
+	if dsn == "" {
+		dsn = config.Path
+	}
+	if config.ReadOnly && !strings.Contains(dsn, "access_mode=") {
+		if strings.Contains(dsn, "?") {
+			dsn += "&access_mode=READ_ONLY"
+		} else {
+			dsn += "?access_mode=READ_ONLY"
+		}
+	}
+	return dsn
+}
+
+// LoadConfigFromYAML reads a Config from a YAML file of DuckDB settings, e.g.
+//
+//	dsn: analytics.db
+//	memory_limit: 4GB
+//	threads: 4
+//	temp_directory: /tmp/duckdb
+//	extensions:
+//	  - json
+//
+// dsn and extensions set Config.DSN and Config.Extensions, every other key is a setting.
+func LoadConfigFromYAML(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+
+	config := &Config{Settings: make(map[string]string, len(values))}
+	for name, value := range values {
+		switch name {
+		case "dsn":
+			config.DSN = fmt.Sprint(value)
+		case "extensions":
+			extensions, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: extensions must be a list", ErrInvalidConfig)
+			}
+			for _, extension := range extensions {
+				config.Extensions = append(config.Extensions, fmt.Sprint(extension))
+			}
+		default:
+			switch value.(type) {
+			case map[string]interface{}, []interface{}:
+				return nil, fmt.Errorf("%w: setting %s must be a scalar", ErrInvalidConfig, name)
+			case nil:
+				config.Settings[name] = ""
+			default:
+				config.Settings[name] = fmt.Sprint(value)
+			}
+		}
+	}
+	return config, nil
+}
+
+// Validate checks the names of config's settings with current_setting(), queried through
+// config.Conn or an in-memory database, so aliases such as memory_limit for max_memory are
+// accepted. Unknown names are reported with ErrUnknownSetting.
+func (config *Config) Validate() error {
+	conn := config.Conn
+	if conn == nil {
+		driverName := config.DriverName
+		if driverName == "" {
+			driverName = DriverName
+		}
+		sqlDB, err := sql.Open(driverName, "")
+		if err != nil {
+			return err
+		}
+		defer sqlDB.Close()
+		conn = sqlDB
+	}
+
+	var unknown []string
+	for name := range config.Settings {
+		var value interface{}
+		if err := conn.QueryRowContext(context.Background(), "SELECT current_setting(?)", name).Scan(&value); err != nil {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %s", ErrUnknownSetting, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// apply runs SET GLOBAL for Threads, MaxMemoryMB and the settings, sorted by name, so they hold
+// for every connection of the pool, then loads the extensions. Settings take precedence over
+// Threads and MaxMemoryMB.
+func (config *Config) apply(conn gorm.ConnPool) error {
+	settings := make(map[string]string, len(config.Settings)+2)
+	if config.Threads > 0 {
+		settings["threads"] = strconv.Itoa(config.Threads)
+	}
+	if config.MaxMemoryMB > 0 {
+		settings["memory_limit"] = strconv.Itoa(config.MaxMemoryMB) + "MiB"
+	}
+	for name, value := range config.Settings {
+		settings[name] = value
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		if err := validIdentifier(name); err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ctx := context.Background()
+	for _, name := range names {
+		value := strings.ReplaceAll(settings[name], "'", "''")
+		if _, err := conn.ExecContext(ctx, "SET GLOBAL "+name+" = '"+value+"'"); err != nil {
+			return err
+		}
+	}
+
+	for _, extension := range config.Extensions {
+		if err := validIdentifier(extension); err != nil {
+			return err
+		}
+		if err := loadExtension(ctx, conn, extension); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadExtension loads extension, installing it first unless it is already installed, like the
+// extensions bundled with the driver, as INSTALL downloads it and fails offline.
+func loadExtension(ctx context.Context, conn gorm.ConnPool, extension string) error {
+	var installed bool
+	if err := conn.QueryRowContext(ctx,
+		"SELECT installed FROM duckdb_extensions() WHERE extension_name = ?", extension,
+	).Scan(&installed); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if !installed {
+		if _, err := conn.ExecContext(ctx, "INSTALL "+extension); err != nil {
+			return err
+		}
+	}
+	_, err := conn.ExecContext(ctx, "LOAD "+extension)
+	return err
+}
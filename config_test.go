@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestLoadConfigFromYAML verifies the YAML settings are validated and applied when the database is opened.
+func TestLoadConfigFromYAML(t *testing.T) {
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "duckdb.yaml")
+	content := "dsn: test.db\nthreads: 2\nmemory_limit: 512MB\ntemp_directory: " + tempDir + "\nextensions:\n  - json\n"
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	config, err := duckdb.LoadConfigFromYAML(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "test.db", config.DSN)
+	assert.Equal(t, []string{"json"}, config.Extensions)
+	assert.Equal(t, map[string]string{"threads": "2", "memory_limit": "512MB", "temp_directory": tempDir}, config.Settings)
+	assert.NoError(t, config.Validate())
+
+	db, err := gorm.Open(duckdb.OpenWithConfig(*config), &gorm.Config{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer closeDB(t, db)
+
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxIdleConns(0)
+
+	// the settings are global, a new connection of the pool sees them too
+	var threads, tempDirectory string
+	assert.NoError(t, db.Raw("SELECT current_setting('threads')::VARCHAR, current_setting('temp_directory')").Row().Scan(&threads, &tempDirectory))
+	assert.Equal(t, "2", threads)
+	assert.Equal(t, tempDir, tempDirectory)
+
+	var loaded bool
+	assert.NoError(t, db.Raw("SELECT loaded FROM duckdb_extensions() WHERE extension_name = 'json'").Row().Scan(&loaded))
+	assert.True(t, loaded)
+
+	config.Settings["treads"] = "4"
+	assert.ErrorIs(t, config.Validate(), duckdb.ErrUnknownSetting)
+
+	assert.NoError(t, os.WriteFile(file, []byte("threads:\n  - 1\n"), 0o600))
+	_, err = duckdb.LoadConfigFromYAML(file)
+	assert.ErrorIs(t, err, duckdb.ErrInvalidConfig)
+}
+
+type Note struct {
+	Body string `gorm:"column:body"`
+}
+
+// TestOpenWithConfig verifies the typed options are applied and a read-only database rejects
+// writes, with ErrReadOnlyMode for migrations.
+func TestOpenWithConfig(t *testing.T) {
+	db, err := gorm.Open(duckdb.OpenWithConfig(duckdb.Config{Path: "test.db", Threads: 2, MaxMemoryMB: 256}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	var threads, memoryLimit string
+	assert.NoError(t, db.Raw("SELECT current_setting('threads')::VARCHAR, current_setting('memory_limit')").Row().Scan(&threads, &memoryLimit))
+	assert.Equal(t, "2", threads)
+	assert.Equal(t, "256.0 MiB", memoryLimit)
+
+	assert.NoError(t, db.Exec("CREATE TABLE notes (body VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO notes VALUES ('kept')").Error)
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, sqlDB.Close())
+
+	readOnly, err := gorm.Open(duckdb.OpenWithConfig(duckdb.Config{Path: "test.db", ReadOnly: true}), &gorm.Config{})
+	assert.NoError(t, err)
+	defer closeDB(t, readOnly)
+
+	var body string
+	assert.NoError(t, readOnly.Raw("SELECT body FROM notes").Row().Scan(&body))
+	assert.Equal(t, "kept", body)
+	assert.Error(t, readOnly.Exec("INSERT INTO notes VALUES ('lost')").Error)
+
+	assert.NoError(t, readOnly.AutoMigrate(&Note{}))
+	assert.ErrorIs(t, readOnly.AutoMigrate(&Product{}), duckdb.ErrReadOnlyMode)
+	assert.ErrorIs(t, readOnly.Migrator().DropTable(&Note{}), duckdb.ErrReadOnlyMode)
+	assert.ErrorIs(t, readOnly.Migrator().CreateIndex(&Note{}, "Body"), duckdb.ErrReadOnlyMode)
+	assert.True(t, readOnly.Migrator().HasTable(&Note{}))
+}
@@ -0,0 +1,339 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrColumnMapping = errors.New("invalid column mapping")
+
+type ExportFormat string
+
+const (
+	FormatCSV     ExportFormat = "CSV"
+	FormatParquet ExportFormat = "PARQUET"
+	FormatJSON    ExportFormat = "JSON"
+)
+
+// stringLiteral renders str as a quoted SQL string, for statements like COPY
+// whose file paths and options can't be bound as parameters.
+func stringLiteral(str string) clause.Expr {
+	return clause.Expr{SQL: "'" + strings.ReplaceAll(str, "'", "''") + "'"}
+}
+
+// CopyToSplitBy writes the result of query into outputDir as one file per distinct value of splitBy,
+// using hive partitioning (outputDir/col=value/data_0.csv). It returns the paths of the files written,
+// files already under outputDir are left out.
+func CopyToSplitBy(db *gorm.DB, query *gorm.DB, outputDir string, splitBy string, format ExportFormat) ([]string, error) {
+	if err := validIdentifier(splitBy); err != nil {
+		return nil, err
+	}
+	if err := validIdentifier(string(format)); err != nil {
+		return nil, err
+	}
+
+	existing, err := listFiles(outputDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	skip := make(map[string]bool, len(existing))
+	for _, file := range existing {
+		skip[file] = true
+	}
+
+	if err := db.Exec(
+		"COPY (?) TO ? (FORMAT "+string(format)+", PARTITION_BY ("+splitBy+"))",
+		query, stringLiteral(outputDir),
+	).Error; err != nil {
+		return nil, err
+	}
+
+	written, err := listFiles(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(written))
+	for _, file := range written {
+		if !skip[file] {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// listFiles returns the paths of all files under dir.
+func listFiles(dir string) (files []string, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// BulkImportOptions configures COPY ... FROM, the zero value imports a CSV file without header.
+type BulkImportOptions struct {
+	Format     ExportFormat
+	Header     bool
+	Delimiter  string
+	SkipRows   int
+	SampleRows int
+	NullString string
+	// DateFormat is a strftime format like %d/%m/%Y for DATE columns.
+	DateFormat string
+}
+
+// Skip skips the first n lines of a CSV file, e.g. comments or a multi-line header.
+func (opts BulkImportOptions) Skip(n int) BulkImportOptions {
+	opts.SkipRows = n
+	return opts
+}
+
+// SampleSize detects the CSV dialect from the first n rows, -1 samples the whole file.
+func (opts BulkImportOptions) SampleSize(n int) BulkImportOptions {
+	opts.SampleRows = n
+	return opts
+}
+
+func (opts BulkImportOptions) build() (string, error) {
+	format := opts.Format
+	if format == "" {
+		format = FormatCSV
+	}
+	if err := validIdentifier(string(format)); err != nil {
+		return "", err
+	}
+
+	options := []string{"FORMAT " + string(format)}
+	if format == FormatCSV {
+		if opts.Header {
+			options = append(options, "HEADER")
+		}
+		if opts.Delimiter != "" {
+			options = append(options, "DELIMITER "+stringLiteral(opts.Delimiter).SQL)
+		}
+		if opts.SkipRows > 0 {
+			options = append(options, "SKIP "+strconv.Itoa(opts.SkipRows))
+		}
+		if opts.SampleRows != 0 {
+			options = append(options, "SAMPLE_SIZE "+strconv.Itoa(opts.SampleRows))
+		}
+		if opts.NullString != "" {
+			options = append(options, "NULLSTR "+stringLiteral(opts.NullString).SQL)
+		}
+		if opts.DateFormat != "" {
+			options = append(options, "DATEFORMAT "+stringLiteral(opts.DateFormat).SQL)
+		}
+	}
+	return "(" + strings.Join(options, ", ") + ")", nil
+}
+
+// BulkImport loads filePath into tableName with COPY ... FROM.
+func BulkImport(db *gorm.DB, tableName, filePath string, opts BulkImportOptions) error {
+	options, err := opts.build()
+	if err != nil {
+		return err
+	}
+	return db.Exec("COPY ? FROM ? "+options, clause.Table{Name: tableName}, stringLiteral(filePath)).Error
+}
+
+// ImportWithColumnMapping loads the CSV file filePath into tableName, renaming file columns
+// to table columns by columnMapping, header columns missing from the mapping keep their name.
+// The header is read to list the table columns in file order, as COPY t(col, ...) FROM
+// assigns file columns by position.
+func ImportWithColumnMapping(db *gorm.DB, tableName string, filePath string, columnMapping map[string]string, opts BulkImportOptions) error {
+	if opts.Format != "" && opts.Format != FormatCSV {
+		return fmt.Errorf("%w: %s files are not supported", ErrColumnMapping, opts.Format)
+	}
+	opts.Header = true
+
+	header, err := readCSVHeader(filePath, opts)
+	if err != nil {
+		return err
+	}
+
+	columns := make([]interface{}, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		seen[name] = true
+		if mapped, ok := columnMapping[name]; ok {
+			name = mapped
+		}
+		columns[i] = clause.Column{Name: name}
+	}
+	for name := range columnMapping {
+		if !seen[name] {
+			return fmt.Errorf("%w: column %s not found in %s", ErrColumnMapping, name, filePath)
+		}
+	}
+
+	options, err := opts.build()
+	if err != nil {
+		return err
+	}
+	return db.Exec("COPY ? ? FROM ? "+options, clause.Table{Name: tableName}, columns, stringLiteral(filePath)).Error
+}
+
+// readCSVHeader returns the column names of the first line after the skipped lines.
+func readCSVHeader(filePath string, opts BulkImportOptions) ([]string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 0; scanner.Scan(); line++ {
+		if line < opts.SkipRows {
+			continue
+		}
+
+		reader := csv.NewReader(strings.NewReader(scanner.Text()))
+		if delimiter := []rune(opts.Delimiter); len(delimiter) == 1 {
+			reader.Comma = delimiter[0]
+		}
+		header, err := reader.Read()
+		if err != nil {
+			return nil, err
+		}
+		for i := range header {
+			header[i] = strings.TrimSpace(header[i])
+		}
+		return header, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: %s has no header", ErrColumnMapping, filePath)
+}
+
+// ImportFormat is the format of imported files.
+type ImportFormat = ExportFormat
+
+// FileImportError is the failure to import one file of ParallelImport.
+type FileImportError struct {
+	File string
+	Err  error
+}
+
+func (e FileImportError) Error() string {
+	return e.File + ": " + e.Err.Error()
+}
+
+func (e FileImportError) Unwrap() error {
+	return e.Err
+}
+
+// ParallelImportResult is the outcome of ParallelImport, Errors are in the order of the files.
+type ParallelImportResult struct {
+	TotalRows int64
+	Errors    []FileImportError
+}
+
+// ParallelImport loads files into tableName with up to goroutines concurrent COPY ... FROM statements,
+// each on its own connection of db's pool, so the pool should allow that many open connections.
+// Failed files are reported in the result and don't stop the others; the returned error is only set
+// when the pool can't be used or format isn't an identifier. tableName is quoted part by part.
+func ParallelImport(db *gorm.DB, tableName string, files []string, goroutines int, format ImportFormat) (*ParallelImportResult, error) {
+	options, err := BulkImportOptions{Format: format}.build()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if goroutines <= 0 {
+		goroutines = 1
+	}
+	if goroutines > len(files) {
+		goroutines = len(files)
+	}
+
+	var (
+		ctx   = db.Statement.Context
+		table = quoteQualifiedName(tableName)
+		total int64
+		errs  = make([]error, len(files))
+		next  = make(chan int)
+		wg    sync.WaitGroup
+	)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			conn, err := sqlDB.Conn(ctx)
+			for idx := range next {
+				if err != nil {
+					errs[idx] = err
+					continue
+				}
+				result, execErr := conn.ExecContext(ctx, "COPY "+table+" FROM "+stringLiteral(files[idx]).SQL+" "+options)
+				if execErr != nil {
+					errs[idx] = execErr
+					continue
+				}
+				if rows, rowsErr := result.RowsAffected(); rowsErr == nil {
+					atomic.AddInt64(&total, rows)
+				}
+			}
+			if conn != nil {
+				_ = conn.Close()
+			}
+		}()
+	}
+
+	for idx := range files {
+		next <- idx
+	}
+	close(next)
+	wg.Wait()
+
+	result := &ParallelImportResult{TotalRows: total}
+	for idx, err := range errs {
+		if err != nil {
+			result.Errors = append(result.Errors, FileImportError{File: files[idx], Err: err})
+		}
+	}
+	return result, nil
+}
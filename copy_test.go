@@ -0,0 +1,184 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type Event struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name      string    `gorm:"column:name"`
+	EventDate time.Time `gorm:"column:event_date;type:date"`
+}
+
+// TestCopyToSplitBy verifies one file is written per date and each file only holds that date's rows.
+func TestCopyToSplitBy(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Event{}))
+
+	day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Name: "2024-01-01 a", EventDate: day1},
+		{Name: "2024-01-01 b", EventDate: day1},
+		{Name: "2024-01-01 c", EventDate: day1},
+		{Name: "2024-01-02 a", EventDate: day2},
+		{Name: "2024-01-02 b", EventDate: day2},
+	}
+	assert.NoError(t, db.Create(&events).Error)
+
+	outputDir := filepath.Join(t.TempDir(), "events")
+	query := db.Model(&Event{}).Select("name", "event_date")
+	files, err := duckdb.CopyToSplitBy(db, query, outputDir, "event_date", duckdb.FormatCSV)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	_, err = duckdb.CopyToSplitBy(db, query, outputDir, "event_date); DROP TABLE events; --", duckdb.FormatCSV)
+	assert.ErrorIs(t, err, duckdb.ErrInvalidIdentifier)
+	_, err = duckdb.CopyToSplitBy(db, query, outputDir, "event_date", "CSV, OVERWRITE")
+	assert.ErrorIs(t, err, duckdb.ErrInvalidIdentifier)
+
+	expected := map[string]int{"2024-01-01": 3, "2024-01-02": 2}
+	for _, file := range files {
+		partition := filepath.Base(filepath.Dir(file))
+		date := strings.TrimPrefix(partition, "event_date=")
+		assert.Contains(t, expected, date)
+
+		var names []string
+		assert.NoError(t, db.Raw("SELECT name FROM read_csv(?)", file).Scan(&names).Error)
+		assert.Len(t, names, expected[date])
+		for _, name := range names {
+			assert.True(t, strings.HasPrefix(name, date), name)
+		}
+	}
+}
+
+// TestBulkImportSkip verifies leading lines are skipped and data starts from the correct row.
+func TestBulkImportSkip(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&PoolItem{}))
+
+	file := filepath.Join(t.TempDir(), "items.csv")
+	content := "inventory report\ngenerated 2024-01-01\nname,qty\nbolt,1\nnut,2\nscrew,3\n"
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	opts := duckdb.BulkImportOptions{}.Skip(3).SampleSize(10)
+	assert.NoError(t, duckdb.BulkImport(db, "pool_items", file, opts))
+
+	var items []PoolItem
+	assert.NoError(t, db.Order("qty").Find(&items).Error)
+	assert.Len(t, items, 3)
+	assert.Equal(t, PoolItem{Name: "bolt", Qty: 1}, items[0])
+	assert.Equal(t, PoolItem{Name: "screw", Qty: 3}, items[2])
+
+	err := duckdb.BulkImport(db, "pool_items", file, duckdb.BulkImportOptions{Format: "csv); DROP TABLE pool_items; --"})
+	assert.ErrorIs(t, err, duckdb.ErrInvalidIdentifier)
+	assert.True(t, db.Migrator().HasTable(&PoolItem{}))
+}
+
+// TestImportWithColumnMapping verifies file columns land in the mapped table columns regardless of order.
+func TestImportWithColumnMapping(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE mapped (x VARCHAR, y INTEGER, z DOUBLE)").Error)
+
+	file := filepath.Join(t.TempDir(), "abc.csv")
+	assert.NoError(t, os.WriteFile(file, []byte("c,a,b\n1.5,one,1\n2.5,two,2\n"), 0o600))
+
+	mapping := map[string]string{"a": "x", "b": "y", "c": "z"}
+	assert.NoError(t, duckdb.ImportWithColumnMapping(db, "mapped", file, mapping, duckdb.BulkImportOptions{}))
+
+	var rows []struct {
+		X string
+		Y int
+		Z float64
+	}
+	assert.NoError(t, db.Table("mapped").Order("y").Find(&rows).Error)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "one", rows[0].X)
+	assert.Equal(t, 1, rows[0].Y)
+	assert.Equal(t, 1.5, rows[0].Z)
+	assert.Equal(t, "two", rows[1].X)
+	assert.Equal(t, 2.5, rows[1].Z)
+
+	mapping["d"] = "w"
+	err := duckdb.ImportWithColumnMapping(db, "mapped", file, mapping, duckdb.BulkImportOptions{})
+	assert.ErrorIs(t, err, duckdb.ErrColumnMapping)
+}
+
+// TestParallelImport verifies the rows of all files are imported and a failing file is reported.
+func TestParallelImport(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&PoolItem{}))
+
+	dir := t.TempDir()
+	var files []string
+	for i := 0; i < 10; i++ {
+		var content strings.Builder
+		for j := 0; j < 100; j++ {
+			content.WriteString(fmt.Sprintf("item-%d-%d,%d\n", i, j, j))
+		}
+		file := filepath.Join(dir, fmt.Sprintf("items_%d.csv", i))
+		assert.NoError(t, os.WriteFile(file, []byte(content.String()), 0o600))
+		files = append(files, file)
+	}
+
+	result, err := duckdb.ParallelImport(db, "pool_items", files, 4, duckdb.FormatCSV)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, int64(1000), result.TotalRows)
+
+	var count int64
+	assert.NoError(t, db.Model(&PoolItem{}).Count(&count).Error)
+	assert.Equal(t, int64(1000), count)
+
+	missing := filepath.Join(dir, "missing.csv")
+	result, err = duckdb.ParallelImport(db, "pool_items", []string{files[0], missing}, 2, duckdb.FormatCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), result.TotalRows)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, missing, result.Errors[0].File)
+
+	result, err = duckdb.ParallelImport(db, "main.pool_items", files[:1], 1, duckdb.FormatCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), result.TotalRows)
+
+	result, err = duckdb.ParallelImport(db, "pool_items FROM 'x'; DROP TABLE pool_items; --", files[:1], 1, duckdb.FormatCSV)
+	assert.NoError(t, err)
+	assert.Len(t, result.Errors, 1)
+	assert.True(t, db.Migrator().HasTable(&PoolItem{}))
+
+	_, err = duckdb.ParallelImport(db, "pool_items", files[:1], 1, "csv); DROP TABLE pool_items; --")
+	assert.ErrorIs(t, err, duckdb.ErrInvalidIdentifier)
+}
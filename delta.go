@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoadDeltaExtension installs, if needed, and loads the delta extension providing delta_scan.
+func LoadDeltaExtension(db *gorm.DB) error {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return loadExtension(ctx, db.Statement.ConnPool, "delta")
+}
+
+// DeltaScanOptions selects an older snapshot of a Delta Lake table, by Version or,
+// when Version is zero, by TimestampVersion.
+type DeltaScanOptions struct {
+	Version          int64
+	TimestampVersion time.Time
+}
+
+// DeltaTable emits delta_scan('path'), reading the Delta Lake table at path, for use as the table of a query:
+//
+//	db.Table("?", duckdb.DeltaTable("s3://bucket/events")).Find(&events)
+//
+// Time travel through DeltaScanOptions needs a delta extension release supporting it.
+func DeltaTable(path string, opts ...DeltaScanOptions) clause.Expr {
+	sql := "delta_scan(?"
+	vars := []interface{}{stringLiteral(path)}
+	if len(opts) > 0 {
+		switch opt := opts[0]; {
+		case opt.Version > 0:
+			sql += ", version := " + strconv.FormatInt(opt.Version, 10)
+		case !opt.TimestampVersion.IsZero():
+			sql += ", timestamp := ?::TIMESTAMPTZ"
+			vars = append(vars, stringLiteral(opt.TimestampVersion.UTC().Format(time.RFC3339Nano)))
+		}
+	}
+	return clause.Expr{SQL: sql + ")", Vars: vars}
+}
@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestDeltaTable verifies delta_scan is emitted with the path and the time travel options.
+func TestDeltaTable(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	deltaSQL := func(expr interface{}) string {
+		return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			var rows []map[string]interface{}
+			return tx.Table("?", expr).Find(&rows)
+		})
+	}
+
+	assert.Equal(t, "SELECT * FROM delta_scan('data/events')", deltaSQL(duckdb.DeltaTable("data/events")))
+	assert.Equal(t, "SELECT * FROM delta_scan('data/o''brien')", deltaSQL(duckdb.DeltaTable("data/o'brien")))
+	assert.Equal(t, "SELECT * FROM delta_scan('data/events', version := 3)",
+		deltaSQL(duckdb.DeltaTable("data/events", duckdb.DeltaScanOptions{Version: 3})))
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	assert.Equal(t, "SELECT * FROM delta_scan('data/events', timestamp := '2024-05-01T10:00:00Z'::TIMESTAMPTZ)",
+		deltaSQL(duckdb.DeltaTable("data/events", duckdb.DeltaScanOptions{TimestampVersion: at})))
+}
@@ -20,7 +20,9 @@ package duckdb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -35,14 +37,34 @@ import (
 
 const DriverName = "duckdb"
 
+var ErrInvalidVersion = errors.New("invalid duckdb version")
+
+var nestedTypeRegexp = regexp.MustCompile(`(?i)^\s*(struct|map|union)\s*\(|\[\d*\]\s*$`)
+
 type Dialector struct {
 	*Config
 }
 
 type Config struct {
-	DriverName string
-	DSN        string
-	Conn       gorm.ConnPool
+	DriverName    string
+	DSN           string
+	Conn          gorm.ConnPool
+	ServerVersion string
+	// EnableObjectCache runs EnableObjectCache when the dialector is initialized, a no-op since DuckDB 1.4.
+	EnableObjectCache bool
+	// Settings are applied with SET GLOBAL name = 'value' when the dialector is initialized,
+	// so they apply to every connection of the database, not only the one that ran them.
+	Settings map[string]string
+	// Extensions are installed and loaded when the dialector is initialized.
+	Extensions []string
+	// Path is the database file, empty for an in-memory database. It is used when DSN is empty.
+	Path string
+	// ReadOnly opens the database with access_mode=READ_ONLY.
+	ReadOnly bool
+	// MaxMemoryMB sets memory_limit in MiB when positive.
+	MaxMemoryMB int
+	// Threads sets the number of threads of DuckDB when positive.
+	Threads int
 }
 
 func Open(dsn string) gorm.Dialector {
@@ -57,13 +79,43 @@ func (dialector Dialector) Name() string {
 	return DriverName
 }
 
+// DialectVersion returns the major.minor.patch version of the DuckDB library,
+// as reported by SELECT version() when the dialector was initialized.
+func (dialector Dialector) DialectVersion() (string, error) {
+	major, minor, patch, err := parseVersion(dialector.ServerVersion)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d.%d.%d", major, minor, patch), nil
+}
+
+// parseVersion parses versions like v1.1.3 or 1.2.0-dev123.
+func parseVersion(version string) (major, minor, patch int, err error) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".", 3)
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+	if end := strings.IndexFunc(parts[2], func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+		parts[2] = parts[2][:end]
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		if numbers[i], err = strconv.Atoi(part); err != nil {
+			return 0, 0, 0, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+		}
+	}
+	return numbers[0], numbers[1], numbers[2], nil
+}
+
 func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	if dialector.DriverName == "" {
 		dialector.DriverName = DriverName
 	}
 
 	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{
-		CreateClauses: []string{"INSERT", "VALUES", "RETURNING"},
+		CreateClauses: []string{"INSERT", "VALUES", "ON CONFLICT", "RETURNING"},
+		QueryClauses:  []string{"SELECT", "FROM", "WHERE", "GROUP BY", "USING SAMPLE", "ORDER BY", "LIMIT", "FOR"},
 		UpdateClauses: []string{"UPDATE", "SET", "WHERE", "RETURNING"},
 		DeleteClauses: []string{"DELETE", "FROM", "WHERE", "RETURNING"},
 	})
@@ -71,7 +123,7 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	if dialector.Conn != nil {
 		db.ConnPool = dialector.Conn
 	} else {
-		db.ConnPool, err = sql.Open(dialector.DriverName, dialector.DSN)
+		db.ConnPool, err = sql.Open(dialector.DriverName, dialector.Config.dsn())
 		if err != nil {
 			return err
 		}
@@ -81,6 +133,17 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	if err := db.ConnPool.QueryRowContext(context.Background(), "SELECT version()").Scan(&version); err != nil {
 		return err
 	}
+	dialector.ServerVersion = version
+
+	if dialector.Config.EnableObjectCache {
+		if _, err := db.ConnPool.ExecContext(context.Background(), enableObjectCacheSQL); err != nil {
+			return err
+		}
+	}
+
+	if err := dialector.Config.apply(db.ConnPool); err != nil {
+		return err
+	}
 
 	for k, v := range dialector.ClauseBuilders() {
 		db.ClauseBuilders[k] = v
@@ -101,7 +164,9 @@ func (dialector Dialector) ClauseBuilders() map[string]clause.ClauseBuilder {
 
 					_, _ = stmt.WriteString("INTO ")
 					if insert.Table.Name == "" {
-						stmt.WriteQuoted(stmt.Table)
+						// the current table writes stmt.TableExpr when set, which keeps the
+						// schema of a qualified TableName that stmt.Table drops
+						stmt.WriteQuoted(clause.Table{Name: clause.CurrentTable})
 					} else {
 						stmt.WriteQuoted(insert.Table)
 					}
@@ -111,9 +176,13 @@ func (dialector Dialector) ClauseBuilders() map[string]clause.ClauseBuilder {
 
 			c.Build(builder)
 		},
+		"ON CONFLICT": buildOnConflict,
 		"RETURNING": func(c clause.Clause, builder clause.Builder) {
 			if returning, ok := c.Expression.(clause.Returning); ok {
 				_, _ = builder.WriteString("RETURNING ")
+				if len(returning.Columns) == 0 {
+					_ = builder.WriteByte('*')
+				}
 				for idx, column := range returning.Columns {
 					if idx > 0 {
 						_ = builder.WriteByte(',')
@@ -205,6 +274,10 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 		return "timestamptz"
 	case schema.Bytes:
 		return "blob"
+	case listDataType:
+		return listDataTypeOf(field)
+	case uuidDataType:
+		return "uuid"
 	default:
 		if field.Tag.Get("gorm") == "type:jsonb" {
 			return "json"
@@ -216,6 +289,15 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 func (dialector Dialector) getSchemaCustomType(field *schema.Field) string {
 	sqlType := string(field.DataType)
 
+	if _, ok := parseEnumValues(sqlType); ok && field.Schema != nil {
+		return enumTypeName(field)
+	}
+
+	// nested types such as struct(x double, y double) are declared in full by the type tag
+	if nestedTypeRegexp.MatchString(sqlType) {
+		return sqlType
+	}
+
 	if field.AutoIncrement && !strings.Contains(strings.ToLower(sqlType), "integer") {
 		size := field.Size
 		if field.GORMDataType == schema.Uint {
@@ -286,8 +368,10 @@ func (dialector Dialector) QuoteTo(writer clause.Writer, str string) {
 	_, _ = writer.WriteString("")
 }
 
+// Explain inlines vars as single-quoted literals, double quotes would make DuckDB read strings as
+// identifiers, e.g. in the DEFAULT of migrated columns.
 func (dialector Dialector) Explain(sql string, vars ...interface{}) string {
-	return logger.ExplainSQL(sql, nil, `"`, vars...)
+	return logger.ExplainSQL(sql, nil, `'`, vars...)
 }
 
 func (dialectopr Dialector) SavePoint(tx *gorm.DB, name string) error {
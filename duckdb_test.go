@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestDialectorName verifies the dialector reports the duckdb name.
+func TestDialectorName(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.Equal(t, "duckdb", db.Dialector.Name())
+	assert.Equal(t, duckdb.DriverName, db.Dialector.Name())
+}
+
+// TestDialectVersion verifies the version matches SELECT version() as major.minor.patch.
+func TestDialectVersion(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	version, err := db.Dialector.(*duckdb.Dialector).DialectVersion()
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^\d+\.\d+\.\d+$`), version)
+
+	var raw string
+	assert.NoError(t, db.Raw("SELECT version()").Scan(&raw).Error)
+	assert.True(t, strings.HasPrefix(strings.TrimPrefix(raw, "v"), version), raw)
+
+	_, err = duckdb.Dialector{Config: &duckdb.Config{ServerVersion: "unknown"}}.DialectVersion()
+	assert.ErrorIs(t, err, duckdb.ErrInvalidVersion)
+}
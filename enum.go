@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+var (
+	enumTypeRegexp  = regexp.MustCompile(`(?is)^\s*enum\s*\((.*)\)\s*$`)
+	enumValueRegexp = regexp.MustCompile(`'((?:[^']|'')*)'`)
+)
+
+// parseEnumValues returns the values of a MySQL style enum('a','b') data type.
+func parseEnumValues(dataType string) ([]string, bool) {
+	match := enumTypeRegexp.FindStringSubmatch(dataType)
+	if match == nil {
+		return nil, false
+	}
+
+	var values []string
+	for _, value := range enumValueRegexp.FindAllStringSubmatch(match[1], -1) {
+		values = append(values, strings.ReplaceAll(value[1], "''", "'"))
+	}
+	return values, len(values) > 0
+}
+
+// enumTypeName is the name of the ENUM type created for an enum field, <table>_<column>_enum.
+func enumTypeName(field *schema.Field) string {
+	return field.Schema.Table + "_" + field.DBName + "_enum"
+}
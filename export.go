@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CompressionType string
+
+const (
+	CompressionNone   CompressionType = "uncompressed"
+	CompressionGzip   CompressionType = "gzip"
+	CompressionZstd   CompressionType = "zstd"
+	CompressionSnappy CompressionType = "snappy"
+)
+
+// exportDatabaseAlias is the scratch database used to export a subset of the tables.
+const exportDatabaseAlias = "duckdb_export"
+
+type ExportDBOptions struct {
+	Format      ExportFormat
+	Compression CompressionType
+	// Tables limits the export to the listed tables. Those are copied with CREATE TABLE AS,
+	// so only their columns and data are exported, not constraints, defaults or sequences.
+	Tables []string
+}
+
+func (opts ExportDBOptions) build() (string, error) {
+	format := opts.Format
+	if format == "" {
+		format = FormatCSV
+	}
+	if err := validIdentifier(string(format)); err != nil {
+		return "", err
+	}
+
+	options := []string{"FORMAT " + string(format)}
+	if opts.Compression != "" {
+		if err := validIdentifier(string(opts.Compression)); err != nil {
+			return "", err
+		}
+		options = append(options, "COMPRESSION "+string(opts.Compression))
+	}
+	return "(" + strings.Join(options, ", ") + ")", nil
+}
+
+// ExportDatabase writes the schema and data of the database into the directory path
+// with EXPORT DATABASE, to be restored with ImportDatabase.
+func ExportDatabase(db *gorm.DB, path string, opts ExportDBOptions) error {
+	options, err := opts.build()
+	if err != nil {
+		return err
+	}
+	if len(opts.Tables) == 0 {
+		return db.Exec("EXPORT DATABASE ? "+options, stringLiteral(path)).Error
+	}
+
+	return db.Connection(func(tx *gorm.DB) (err error) {
+		if err = tx.Exec("ATTACH ':memory:' AS " + exportDatabaseAlias).Error; err != nil {
+			return err
+		}
+		defer func() {
+			if detachErr := tx.Exec("DETACH " + exportDatabaseAlias).Error; err == nil {
+				err = detachErr
+			}
+		}()
+
+		for _, table := range opts.Tables {
+			if err = tx.Exec("CREATE TABLE ? AS SELECT * FROM ?",
+				clause.Table{Name: exportDatabaseAlias + ".main." + table}, clause.Table{Name: table},
+			).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec("EXPORT DATABASE "+exportDatabaseAlias+" TO ? "+options, stringLiteral(path)).Error
+	})
+}
+
+// ImportDatabase restores a directory written by ExportDatabase with IMPORT DATABASE.
+func ImportDatabase(db *gorm.DB, path string) error {
+	return db.Exec("IMPORT DATABASE ?", stringLiteral(path)).Error
+}
+
+// ExportToParquet exports the whole database into outputDir as Parquet files compressed with compression.
+func ExportToParquet(db *gorm.DB, outputDir string, compression CompressionType) error {
+	return ExportDatabase(db, outputDir, ExportDBOptions{Format: FormatParquet, Compression: compression})
+}
+
+type ImportFromParquetOption struct {
+	// DropExisting drops the views, tables and sequences of the export that already exist before importing.
+	DropExisting bool
+}
+
+// ImportFromParquet restores a directory written by ExportToParquet.
+func ImportFromParquet(db *gorm.DB, inputDir string, opts ...ImportFromParquetOption) error {
+	var opt ImportFromParquetOption
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if opt.DropExisting {
+		if err := dropExportedObjects(db, inputDir); err != nil {
+			return err
+		}
+	}
+	return ImportDatabase(db, inputDir)
+}
+
+// dropExportedObjects drops the objects created by the schema.sql of an export,
+// in reverse order so dependents go first.
+func dropExportedObjects(db *gorm.DB, dir string) error {
+	file, err := os.Open(filepath.Join(dir, "schema.sql"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var drops []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		for _, kind := range []string{"TABLE", "VIEW", "SEQUENCE"} {
+			prefix := "CREATE " + kind + " "
+			if !strings.HasPrefix(line, prefix) {
+				continue
+			}
+			name := strings.TrimPrefix(line, prefix)
+			if end := strings.IndexAny(name, "( ;"); end >= 0 {
+				name = name[:end]
+			}
+			drops = append(drops, "DROP "+kind+" IF EXISTS "+name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := len(drops) - 1; i >= 0; i-- {
+			if err := tx.Exec(drops[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+func initExportTables(t *testing.T, db *gorm.DB) {
+	assert.NoError(t, db.AutoMigrate(&User{}, &Product{}))
+	assert.NoError(t, db.Create(&[]User{{Name: "alice", Email: "alice@example.com"}, {Name: "bob", Email: "bob@example.com"}}).Error)
+	assert.NoError(t, db.Create(&[]Product{{Name: "pen", Price: 1.5}, {Name: "ink", Price: 3}, {Name: "pad", Price: 2}}).Error)
+}
+
+func openMemoryDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(duckdb.Open(""), &gorm.Config{})
+	assert.NoError(t, err)
+	return db
+}
+
+func countRows(t *testing.T, db *gorm.DB, value interface{}) int64 {
+	var count int64
+	assert.NoError(t, db.Model(value).Count(&count).Error)
+	return count
+}
+
+// TestExportImportDatabase verifies a full export restores tables, columns and rows into a new database.
+func TestExportImportDatabase(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initExportTables(t, db)
+
+	for _, format := range []duckdb.ExportFormat{duckdb.FormatCSV, duckdb.FormatParquet} {
+		dir := filepath.Join(t.TempDir(), string(format))
+		opts := duckdb.ExportDBOptions{Format: format}
+		if format == duckdb.FormatParquet {
+			opts.Compression = duckdb.CompressionZstd
+		}
+		assert.NoError(t, duckdb.ExportDatabase(db, dir, opts))
+
+		restored := openMemoryDB(t)
+		assert.NoError(t, duckdb.ImportDatabase(restored, dir))
+
+		assert.True(t, restored.Migrator().HasColumn(&User{}, "email"))
+		assert.True(t, restored.Migrator().HasColumn(&Product{}, "price"))
+		assert.Equal(t, int64(2), countRows(t, restored, &User{}))
+		assert.Equal(t, int64(3), countRows(t, restored, &Product{}))
+
+		// the restored sequence continues after the exported rows
+		user := User{Name: "carol", Email: "carol@example.com"}
+		assert.NoError(t, restored.Create(&user).Error)
+		assert.Equal(t, uint(3), user.ID)
+	}
+}
+
+// TestExportDatabaseTables verifies only the listed tables are exported.
+func TestExportDatabaseTables(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initExportTables(t, db)
+
+	dir := filepath.Join(t.TempDir(), "products")
+	assert.NoError(t, duckdb.ExportDatabase(db, dir, duckdb.ExportDBOptions{Tables: []string{"products"}}))
+
+	restored := openMemoryDB(t)
+	assert.NoError(t, duckdb.ImportDatabase(restored, dir))
+	assert.True(t, restored.Migrator().HasTable(&Product{}))
+	assert.False(t, restored.Migrator().HasTable(&User{}))
+	assert.Equal(t, int64(3), countRows(t, restored, &Product{}))
+
+	var count int64
+	assert.NoError(t, db.Raw("SELECT count(*) FROM duckdb_databases() WHERE database_name = ?", "duckdb_export").Scan(&count).Error)
+	assert.Equal(t, int64(0), count)
+}
+
+// TestExportImportParquet verifies a Parquet export restores every table, replacing existing ones on request.
+func TestExportImportParquet(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initExportTables(t, db)
+
+	dir := filepath.Join(t.TempDir(), "parquet")
+	assert.NoError(t, duckdb.ExportToParquet(db, dir, duckdb.CompressionSnappy))
+	assert.FileExists(t, filepath.Join(dir, "schema.sql"))
+
+	rejected := filepath.Join(t.TempDir(), "rejected")
+	err := duckdb.ExportDatabase(db, rejected, duckdb.ExportDBOptions{Format: "parquet); DROP TABLE users; --"})
+	assert.ErrorIs(t, err, duckdb.ErrInvalidIdentifier)
+	err = duckdb.ExportToParquet(db, rejected, "zstd); DROP TABLE users; --")
+	assert.ErrorIs(t, err, duckdb.ErrInvalidIdentifier)
+	assert.NoDirExists(t, rejected)
+
+	restored := openMemoryDB(t)
+	assert.NoError(t, duckdb.ImportFromParquet(restored, dir))
+	assert.Equal(t, int64(2), countRows(t, restored, &User{}))
+	assert.Equal(t, int64(3), countRows(t, restored, &Product{}))
+
+	assert.NoError(t, restored.Where("name = ?", "pen").Delete(&Product{}).Error)
+	assert.Error(t, duckdb.ImportFromParquet(restored, dir))
+
+	assert.NoError(t, duckdb.ImportFromParquet(restored, dir, duckdb.ImportFromParquetOption{DropExisting: true}))
+	assert.Equal(t, int64(2), countRows(t, restored, &User{}))
+	assert.Equal(t, int64(3), countRows(t, restored, &Product{}))
+}
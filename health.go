@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IsHealthy checks the database behind db, returning whether it is healthy and a message
+// describing the first failed check. The error is only set when the connection can't be reached.
+//
+// DuckDB has no PRAGMA integrity_check like SQLite. It verifies block checksums whenever blocks are
+// read, so corruption surfaces as an error reading the affected data: IsHealthy reads the catalog
+// and the storage metadata of every table, which is cheap as no table data is scanned.
+// A file that is corrupted beyond that, or locked by another process, already fails gorm.Open.
+func IsHealthy(db *gorm.DB) (bool, string, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return false, "", err
+	}
+	if err := sqlDB.Ping(); err != nil {
+		return false, "", err
+	}
+
+	var tables []string
+	if err := db.Raw("SELECT table_name FROM duckdb_tables() WHERE database_name = current_database() AND schema_name = current_schema() AND NOT internal").
+		Scan(&tables).Error; err != nil {
+		return false, "catalog is not readable: " + err.Error(), nil
+	}
+
+	var blocks int64
+	if err := db.Raw("SELECT total_blocks FROM pragma_database_size() WHERE database_name = current_database()").Row().Scan(&blocks); err != nil {
+		return false, "database size is not readable: " + err.Error(), nil
+	}
+
+	for _, table := range tables {
+		var segments int64
+		if err := db.Raw("SELECT count(*) FROM pragma_storage_info(?)", stringLiteral(table)).Row().Scan(&segments); err != nil {
+			return false, "storage of table " + table + " is not readable: " + err.Error(), nil
+		}
+		if err := db.Exec("SELECT 1 FROM ? LIMIT 1", clause.Table{Name: table}).Error; err != nil {
+			return false, "table " + table + " is not readable: " + err.Error(), nil
+		}
+	}
+	return true, "ok", nil
+}
+
+// IsReadable reports whether db answers a trivial query.
+func IsReadable(db *gorm.DB) bool {
+	var one int
+	return db.Raw("SELECT 1").Row().Scan(&one) == nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestIsHealthy verifies a fresh database is healthy and readable, and a closed one is not readable.
+func TestIsHealthy(t *testing.T) {
+	db := initDB(t)
+	assert.NoError(t, db.AutoMigrate(&Product{}))
+	assert.NoError(t, db.Create(&Product{Name: "pen", Price: 1}).Error)
+
+	healthy, message, err := duckdb.IsHealthy(db)
+	assert.NoError(t, err)
+	assert.True(t, healthy, message)
+	assert.Equal(t, "ok", message)
+	assert.True(t, duckdb.IsReadable(db))
+
+	closeDB(t, db)
+	assert.False(t, duckdb.IsReadable(db))
+	healthy, _, err = duckdb.IsHealthy(db)
+	assert.Error(t, err)
+	assert.False(t, healthy)
+}
+
+// TestOpenCorruptedFile verifies a corrupted database file is detected when opening it.
+func TestOpenCorruptedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupted.db")
+	assert.NoError(t, os.WriteFile(path, []byte("this is not a duckdb database file"), 0o600))
+
+	_, err := gorm.Open(duckdb.Open(path), &gorm.Config{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+type HintCustomer struct {
+	ID   uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Name string `gorm:"column:name"`
+}
+
+type HintOrder struct {
+	ID         uint `gorm:"column:id;primaryKey;autoIncrement"`
+	CustomerID uint `gorm:"column:customer_id"`
+	Amount     int  `gorm:"column:amount"`
+}
+
+func initHintTables(t *testing.T, db *gorm.DB) {
+	assert.NoError(t, db.AutoMigrate(&HintCustomer{}, &HintOrder{}))
+	for i := 1; i <= 10; i++ {
+		assert.NoError(t, db.Create(&HintCustomer{Name: "customer"}).Error)
+		assert.NoError(t, db.Create(&HintOrder{CustomerID: uint(i), Amount: i * 10}).Error)
+	}
+}
+
+func explainPlan(t *testing.T, db *gorm.DB, sql string) string {
+	rows, err := db.Raw("EXPLAIN " + sql).Rows()
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var plan strings.Builder
+	for rows.Next() {
+		var key, value string
+		assert.NoError(t, rows.Scan(&key, &value))
+		plan.WriteString(value)
+	}
+	return plan.String()
+}
+
+// TestHintHashJoin verifies the hash join hint is emitted and the plan uses a hash join.
+func TestHintHashJoin(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initHintTables(t, db)
+
+	query := func(tx *gorm.DB, ids *[]uint) *gorm.DB {
+		return tx.Scopes(duckdb.HintHashJoin("hint_orders")).
+			Table("hint_customers").
+			Select("hint_customers.id").
+			Joins("JOIN hint_orders ON hint_orders.customer_id = hint_customers.id").
+			Find(ids)
+	}
+
+	var ids []uint
+	assert.NoError(t, query(db, &ids).Error)
+	assert.Len(t, ids, 10)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return query(tx, &ids)
+	})
+	assert.True(t, strings.HasPrefix(sql, "SELECT /*+ HASH_JOIN(hint_orders) */ hint_customers.id"), sql)
+	assert.Contains(t, explainPlan(t, db, sql), "HASH_JOIN")
+}
+
+// TestHintMergeJoin verifies the merge join hint is emitted, combines with other hints and
+// makes the plan use a merge join for a range predicate.
+func TestHintMergeJoin(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initHintTables(t, db)
+
+	query := func(tx *gorm.DB, ids *[]uint) *gorm.DB {
+		return tx.Scopes(duckdb.HintHashJoin("hint_customers"), duckdb.HintMergeJoin("hint_orders")).
+			Table("hint_customers").
+			Select("hint_customers.id").
+			Joins("JOIN hint_orders ON hint_orders.amount > hint_customers.id").
+			Find(ids)
+	}
+
+	var ids []uint
+	assert.NoError(t, query(db, &ids).Error)
+	assert.NotEmpty(t, ids)
+
+	var threshold string
+	assert.NoError(t, db.Raw("SELECT current_setting('merge_join_threshold')::VARCHAR").Row().Scan(&threshold))
+	assert.Equal(t, "0", threshold)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return query(tx, &ids)
+	})
+	assert.True(t, strings.HasPrefix(sql, "SELECT /*+ HASH_JOIN(hint_customers) MERGE_JOIN(hint_orders) */ hint_customers.id"), sql)
+	assert.Contains(t, explainPlan(t, db, sql), "MERGE_JOIN")
+}
+
+// TestBroadcastJoin verifies the broadcast hint is emitted, disables join reordering and leaves the join result unchanged.
+func TestBroadcastJoin(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initHintTables(t, db)
+
+	query := func(tx *gorm.DB, amounts *[]int) *gorm.DB {
+		return tx.Table("hint_orders").
+			Select("hint_orders.amount").
+			Joins("JOIN hint_customers ON hint_customers.id = hint_orders.customer_id").
+			Where("hint_customers.id <= ?", 3).
+			Order("hint_orders.amount").
+			Find(amounts)
+	}
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var amounts []int
+		return query(tx.Scopes(duckdb.BroadcastJoin("hint_customers")), &amounts)
+	})
+	assert.True(t, strings.HasPrefix(sql, "SELECT /*+ BROADCAST(hint_customers) */ hint_orders.amount"), sql)
+	assert.Contains(t, explainPlan(t, db, sql), "HASH_JOIN")
+
+	var plain, hinted []int
+	assert.NoError(t, query(db, &plain).Error)
+	assert.NoError(t, query(db.Scopes(duckdb.BroadcastJoin("hint_customers")), &hinted).Error)
+	assert.Equal(t, []int{10, 20, 30}, hinted)
+	assert.Equal(t, plain, hinted)
+
+	var disabled string
+	assert.NoError(t, db.Raw("SELECT current_setting('disabled_optimizers')").Row().Scan(&disabled))
+	assert.Equal(t, "join_order", disabled)
+}
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// CreateIndexBackground runs Migrator.CreateIndex(value, name) in a goroutine. The returned channel
+// receives its result and is then closed, the returned func cancels the creation through the context.
+// DuckDB has no CREATE INDEX CONCURRENTLY: writes to the table still block until the index is built,
+// only the caller is free to continue meanwhile.
+func CreateIndexBackground(db *gorm.DB, value interface{}, name string) (<-chan error, func()) {
+	ctx, cancel := context.WithCancel(db.Statement.Context)
+	done := make(chan error, 1)
+
+	go func() {
+		defer close(done)
+		defer cancel()
+		done <- db.WithContext(ctx).Migrator().CreateIndex(value, name)
+	}()
+
+	return done, cancel
+}
@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestCreateIndexBackground verifies the index exists once the result channel is closed.
+func TestCreateIndexBackground(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE indexed_items (id INTEGER, sku VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO indexed_items SELECT range, 'sku-' || range FROM range(100000)").Error)
+	assert.False(t, db.Migrator().HasIndex(&IndexedItem{}, "idx_indexed_items_sku"))
+
+	done, cancel := duckdb.CreateIndexBackground(db, &IndexedItem{}, "idx_indexed_items_sku")
+	defer cancel()
+
+	var results []error
+	for err := range done {
+		results = append(results, err)
+	}
+	assert.Equal(t, []error{nil}, results)
+	assert.True(t, db.Migrator().HasIndex(&IndexedItem{}, "idx_indexed_items_sku"))
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LineageTable is the metadata table TrackLineage records into.
+const LineageTable = "_lineage"
+
+func createLineageTable(db *gorm.DB) error {
+	return db.Exec("CREATE TABLE IF NOT EXISTS " + LineageTable +
+		" (table_name VARCHAR NOT NULL, source_table VARCHAR NOT NULL, recorded_at TIMESTAMPTZ NOT NULL)").Error
+}
+
+// TrackLineage records in the _lineage table that model's table, a model or a table name,
+// was derived from the derivedFrom tables, replacing its previously recorded sources.
+func TrackLineage(db *gorm.DB, model interface{}, derivedFrom []string) error {
+	table, err := tableNameOf(db, model)
+	if err != nil {
+		return err
+	}
+	if err := createLineageTable(db); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM "+LineageTable+" WHERE table_name = ?", table).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		seen := make(map[string]bool, len(derivedFrom))
+		for _, source := range derivedFrom {
+			if seen[source] {
+				continue
+			}
+			seen[source] = true
+			if err := tx.Exec("INSERT INTO "+LineageTable+" (table_name, source_table, recorded_at) VALUES (?, ?, ?)", table, source, now).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetLineage returns the source tables recorded for tableName, sorted by name.
+func GetLineage(db *gorm.DB, tableName string) ([]string, error) {
+	if err := createLineageTable(db); err != nil {
+		return nil, err
+	}
+
+	var sources []string
+	err := db.Raw("SELECT source_table FROM "+LineageTable+" WHERE table_name = ? ORDER BY source_table", tableName).Scan(&sources).Error
+	return sources, err
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestTrackLineage verifies the recorded sources of a CTAS table are read back and can be replaced.
+func TestTrackLineage(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&User{}, &Product{}))
+	assert.NoError(t, db.Exec("CREATE TABLE user_products AS SELECT users.name, products.name AS product FROM users CROSS JOIN products").Error)
+
+	sources, err := duckdb.GetLineage(db, "user_products")
+	assert.NoError(t, err)
+	assert.Empty(t, sources)
+
+	assert.NoError(t, duckdb.TrackLineage(db, "user_products", []string{"users", "products", "users"}))
+	sources, err = duckdb.GetLineage(db, "user_products")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"products", "users"}, sources)
+
+	assert.NoError(t, duckdb.TrackLineage(db, &Product{}, []string{"raw_products"}))
+	assert.NoError(t, duckdb.TrackLineage(db, "user_products", []string{"users"}))
+
+	sources, err = duckdb.GetLineage(db, "user_products")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"users"}, sources)
+
+	sources, err = duckdb.GetLineage(db, "products")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"raw_products"}, sources)
+}
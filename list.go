@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// listDataType is the gorm data type of List fields, mapped to a DuckDB LIST by DataTypeOf.
+const listDataType schema.DataType = "list"
+
+// List is a field type for DuckDB LIST columns, e.g. List[int64] for BIGINT[]:
+//
+//	type Post struct {
+//		ID     uint
+//		TagIDs duckdb.List[int64]
+//	}
+//
+// Plain slices can't be used, as gorm expands slice values into (v1, v2, ...) and the driver
+// returns LIST values as []interface{}, which List converts back to its element type.
+// The column type follows the element type unless set with the type tag, e.g. type:integer[].
+type List[T any] []T
+
+func (List[T]) GormDataType() string {
+	return string(listDataType)
+}
+
+func (l List[T]) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return []T(l), nil
+}
+
+func (l *List[T]) Scan(src interface{}) error {
+	if src == nil {
+		*l = nil
+		return nil
+	}
+
+	values, ok := src.([]interface{})
+	if !ok {
+		return fmt.Errorf("failed to scan %T into %T", src, l)
+	}
+
+	list := make(List[T], len(values))
+	elemType := reflect.TypeOf(list).Elem()
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		rv := reflect.ValueOf(value)
+		if !rv.Type().ConvertibleTo(elemType) {
+			return fmt.Errorf("failed to scan list element %T into %v", value, elemType)
+		}
+		reflect.ValueOf(&list[i]).Elem().Set(rv.Convert(elemType))
+	}
+	*l = list
+	return nil
+}
+
+// listDataTypeOf returns the DuckDB LIST type of a slice field from its element type.
+func listDataTypeOf(field *schema.Field) string {
+	elemType := field.IndirectFieldType.Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	switch elemType.Kind() {
+	case reflect.Bool:
+		return "boolean[]"
+	case reflect.Int8:
+		return "tinyint[]"
+	case reflect.Int16:
+		return "smallint[]"
+	case reflect.Int32:
+		return "integer[]"
+	case reflect.Int, reflect.Int64:
+		return "bigint[]"
+	case reflect.Uint8:
+		return "utinyint[]"
+	case reflect.Uint16:
+		return "usmallint[]"
+	case reflect.Uint32:
+		return "uinteger[]"
+	case reflect.Uint, reflect.Uint64:
+		return "ubigint[]"
+	case reflect.Float32:
+		return "float[]"
+	case reflect.Float64:
+		return "double[]"
+	case reflect.Struct:
+		if elemType.ConvertibleTo(reflect.TypeOf(time.Time{})) {
+			return "timestamptz[]"
+		}
+	}
+	return "varchar[]"
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type ListRow struct {
+	ID      uint                `gorm:"column:id;primaryKey;autoIncrement"`
+	Scores  duckdb.List[int64]  `gorm:"column:scores;type:integer[]"`
+	Tags    duckdb.List[string] `gorm:"column:tags"`
+	Weights duckdb.List[float64]
+}
+
+// TestListRoundTrip verifies LIST columns are created from the field types and values survive a round trip.
+func TestListRoundTrip(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&ListRow{}))
+
+	var types []struct {
+		ColumnName string
+		ColumnType string
+	}
+	assert.NoError(t, db.Raw("DESCRIBE list_rows").Scan(&types).Error)
+	columnTypes := map[string]string{}
+	for _, column := range types {
+		columnTypes[column.ColumnName] = column.ColumnType
+	}
+	assert.Equal(t, "INTEGER[]", columnTypes["scores"])
+	assert.Equal(t, "VARCHAR[]", columnTypes["tags"])
+	assert.Equal(t, "DOUBLE[]", columnTypes["weights"])
+
+	rows := []ListRow{
+		{Scores: duckdb.List[int64]{3, 1, 2}, Tags: duckdb.List[string]{"a", "b"}, Weights: duckdb.List[float64]{0.5}},
+		{Scores: duckdb.List[int64]{}, Tags: nil},
+	}
+	assert.NoError(t, db.Create(&rows).Error)
+
+	var found []ListRow
+	assert.NoError(t, db.Order("id").Find(&found).Error)
+	assert.Len(t, found, 2)
+	assert.Equal(t, duckdb.List[int64]{3, 1, 2}, found[0].Scores)
+	assert.Equal(t, duckdb.List[string]{"a", "b"}, found[0].Tags)
+	assert.Equal(t, duckdb.List[float64]{0.5}, found[0].Weights)
+	assert.Equal(t, duckdb.List[int64]{}, found[1].Scores)
+	assert.Nil(t, found[1].Tags)
+
+	var count int64
+	assert.NoError(t, db.Model(&ListRow{}).Where("list_contains(scores, ?)", 2).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
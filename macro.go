@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// CallTableMacro emits name(args...), a call of a table macro created with
+// CREATE MACRO name(...) AS TABLE ..., for use as the table of a query:
+//
+//	db.Table("?", duckdb.CallTableMacro("orders_above", 100)).Find(&orders)
+//
+// name is quoted, a schema-qualified name like reports.orders_above is quoted part by part.
+func CallTableMacro(name string, args ...interface{}) clause.Expr {
+	placeholders := make([]string, len(args))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	return clause.Expr{SQL: quoteQualifiedName(name) + "(" + strings.Join(placeholders, ", ") + ")", Vars: args}
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestCallTableMacro verifies a table macro called as the query table filters by its argument.
+func TestCallTableMacro(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 10)
+
+	assert.NoError(t, db.Exec("CREATE MACRO rows_above(x) AS TABLE SELECT * FROM sample_rows WHERE id > x").Error)
+
+	var rows []SampleRow
+	assert.NoError(t, db.Table("?", duckdb.CallTableMacro("rows_above", 6)).Order("id").Find(&rows).Error)
+	assert.Equal(t, []SampleRow{{ID: 7}, {ID: 8}, {ID: 9}}, rows)
+
+	var count int64
+	assert.NoError(t, db.Table("? AS r", duckdb.CallTableMacro("rows_above", 8)).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("?", duckdb.CallTableMacro("rows_above(0); DROP TABLE sample_rows; --", 1)).Find(&rows)
+	})
+	assert.Contains(t, sql, `FROM "rows_above(0); DROP TABLE sample_rows; --"(1)`)
+
+	assert.NoError(t, db.Table("?", duckdb.CallTableMacro("main.rows_above", 8)).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+// TestCreateDropMacro verifies scalar and table macros are created, replaced, found and dropped by name.
+func TestCreateDropMacro(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 10)
+
+	m := db.Migrator().(duckdb.Migrator)
+	assert.NoError(t, m.CreateMacro("add_tax", []string{"amount"}, "amount * 1.1", false))
+	assert.NoError(t, m.CreateMacro("add_tax", []string{"amount", "rate"}, "amount * (1 + rate)", false))
+	assert.NoError(t, m.CreateMacro("rows_below", []string{"x"}, "SELECT * FROM sample_rows WHERE id < x", true))
+	assert.True(t, m.HasMacro("add_tax"))
+	assert.True(t, m.HasMacro("rows_below"))
+	assert.False(t, m.HasMacro("abs"))
+
+	var total float64
+	assert.NoError(t, db.Raw("SELECT add_tax(100, 0.5)").Scan(&total).Error)
+	assert.Equal(t, 150.0, total)
+
+	var rows []SampleRow
+	assert.NoError(t, db.Table("?", duckdb.CallTableMacro("rows_below", 2)).Order("id").Find(&rows).Error)
+	assert.Equal(t, []SampleRow{{ID: 0}, {ID: 1}}, rows)
+
+	assert.NoError(t, m.DropMacro("add_tax"))
+	assert.NoError(t, m.DropMacro("rows_below"))
+	assert.NoError(t, m.DropMacro("rows_below"))
+	assert.False(t, m.HasMacro("add_tax"))
+	assert.False(t, m.HasMacro("rows_below"))
+
+	assert.ErrorIs(t, m.CreateMacro("bad name", nil, "1", false), duckdb.ErrInvalidIdentifier)
+}
@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Map is a field type for DuckDB MAP columns, declared with the type tag:
+//
+//	type Product struct {
+//		ID     uint
+//		Stock  duckdb.Map[string, int64] `gorm:"type:map(varchar, bigint)"`
+//	}
+//
+// The driver can't bind MAP parameters, so values are written as map([k, ...], [v, ...])
+// with bound keys and values, cast to the DuckDB types of K and V since DuckDB can't infer the
+// types of parameters inside a map. The map[interface{}]interface{} the driver returns
+// is converted back to the key and value types.
+type Map[K comparable, V any] map[K]V
+
+func (m Map[K, V]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if m == nil {
+		return clause.Expr{SQL: "NULL"}
+	}
+
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+
+	if len(keys) == 0 {
+		return clause.Expr{SQL: "MAP {}"}
+	}
+
+	// DuckDB numbers the placeholders of a MAP {k: v} literal keys first, so keys and values
+	// are written as two lists whose vars line up with the placeholders
+	keyPlaceholders := make([]string, len(keys))
+	valuePlaceholders := make([]string, len(keys))
+	keyPlaceholder := castPlaceholder(reflect.TypeOf((*K)(nil)).Elem())
+	valuePlaceholder := castPlaceholder(reflect.TypeOf((*V)(nil)).Elem())
+	vars := make([]interface{}, 2*len(keys))
+	for i, key := range keys {
+		keyPlaceholders[i], valuePlaceholders[i] = keyPlaceholder, valuePlaceholder
+		vars[i], vars[len(keys)+i] = key, m[key]
+	}
+	return clause.Expr{
+		SQL:  "map([" + strings.Join(keyPlaceholders, ", ") + "], [" + strings.Join(valuePlaceholders, ", ") + "])",
+		Vars: vars,
+	}
+}
+
+var kindTypes = map[reflect.Kind]string{
+	reflect.Bool:    "BOOLEAN",
+	reflect.Int8:    "TINYINT",
+	reflect.Int16:   "SMALLINT",
+	reflect.Int32:   "INTEGER",
+	reflect.Int:     "BIGINT",
+	reflect.Int64:   "BIGINT",
+	reflect.Uint8:   "UTINYINT",
+	reflect.Uint16:  "USMALLINT",
+	reflect.Uint32:  "UINTEGER",
+	reflect.Uint:    "UBIGINT",
+	reflect.Uint64:  "UBIGINT",
+	reflect.Float32: "FLOAT",
+	reflect.Float64: "DOUBLE",
+	reflect.String:  "VARCHAR",
+}
+
+// castPlaceholder returns a placeholder cast to the DuckDB type of typ, or a bare placeholder
+// for types without a plain DuckDB counterpart.
+func castPlaceholder(typ reflect.Type) string {
+	if name, ok := kindTypes[typ.Kind()]; ok {
+		return "CAST(? AS " + name + ")"
+	}
+	return "?"
+}
+
+func (m *Map[K, V]) Scan(src interface{}) error {
+	if src == nil {
+		*m = nil
+		return nil
+	}
+
+	rv := reflect.ValueOf(src)
+	if rv.Kind() != reflect.Map {
+		return fmt.Errorf("failed to scan %T into %T", src, m)
+	}
+
+	result := make(Map[K, V], rv.Len())
+	keyType, valueType := reflect.TypeOf(result).Key(), reflect.TypeOf(result).Elem()
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := convertScanned(iter.Key(), keyType)
+		if err != nil {
+			return err
+		}
+		value, err := convertScanned(iter.Value(), valueType)
+		if err != nil {
+			return err
+		}
+		result[key.Interface().(K)] = value.Interface().(V)
+	}
+	*m = result
+	return nil
+}
+
+// convertScanned converts a value returned by the driver, unwrapping interfaces, to typ.
+func convertScanned(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Interface {
+		return reflect.Zero(typ), nil
+	}
+	if !v.Type().ConvertibleTo(typ) {
+		return reflect.Value{}, fmt.Errorf("failed to convert %v into %v", v.Type(), typ)
+	}
+	return v.Convert(typ), nil
+}
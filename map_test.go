@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type MapRow struct {
+	ID    uint                      `gorm:"column:id;primaryKey;autoIncrement"`
+	Stock duckdb.Map[string, int64] `gorm:"column:stock;type:map(varchar, bigint)"`
+}
+
+// TestMapRoundTrip verifies MAP columns are created from the type tag and map contents survive a round trip.
+func TestMapRoundTrip(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&MapRow{}))
+
+	var columnType string
+	assert.NoError(t, db.Raw("SELECT data_type FROM duckdb_columns() WHERE table_name = ? AND column_name = ?", "map_rows", "stock").Row().Scan(&columnType))
+	assert.Equal(t, "MAP(VARCHAR, BIGINT)", columnType)
+
+	rows := []MapRow{
+		{Stock: duckdb.Map[string, int64]{"bolt": 10, "nut": 25}},
+		{Stock: duckdb.Map[string, int64]{}},
+	}
+	assert.NoError(t, db.Create(&rows).Error)
+
+	var found []MapRow
+	assert.NoError(t, db.Order("id").Find(&found).Error)
+	if !assert.Len(t, found, 2) {
+		return
+	}
+	assert.Equal(t, duckdb.Map[string, int64]{"bolt": 10, "nut": 25}, found[0].Stock)
+	assert.Equal(t, duckdb.Map[string, int64]{}, found[1].Stock)
+
+	var entries int64
+	assert.NoError(t, db.Model(&MapRow{}).Where("id = ?", rows[0].ID).Select("cardinality(stock)").Row().Scan(&entries))
+	assert.Equal(t, int64(2), entries)
+
+	assert.NoError(t, db.AutoMigrate(&MapRow{}))
+}
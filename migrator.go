@@ -21,6 +21,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"gorm.io/gorm"
@@ -31,26 +32,65 @@ import (
 
 var ErrDuckDBNotSupported = errors.New("DuckDB are not supported this operation")
 
+var ErrIndexNotFound = errors.New("index not found")
+
+var ErrTruncateTable = errors.New("failed to truncate table")
+
+var ErrReadOnlyMode = errors.New("database is opened read-only")
+
+var nextvalRegexp = regexp.MustCompile(`(?i)^nextval\('((?:[^']|'')+)'`)
+
 var typeAliasMap = map[string][]string{
 	"int":                      {"integer"},
 	"int2":                     {"smallint"},
 	"int4":                     {"integer"},
 	"int8":                     {"bigint"},
-	"smallint":                 {"int2"},
-	"integer":                  {"int4"},
-	"bigint":                   {"int8"},
+	"int16":                    {"smallint"},
+	"int32":                    {"integer"},
+	"int64":                    {"bigint"},
+	"int128":                   {"hugeint"},
+	"uint8":                    {"utinyint"},
+	"uint16":                   {"usmallint"},
+	"uint32":                   {"uinteger"},
+	"uint64":                   {"ubigint"},
+	"uint128":                  {"uhugeint"},
+	"smallint":                 {"int2", "int16"},
+	"integer":                  {"int4", "int32"},
+	"bigint":                   {"int8", "int64"},
+	"hugeint":                  {"int128"},
+	"utinyint":                 {"uint8"},
+	"usmallint":                {"uint16"},
+	"uinteger":                 {"uint32"},
+	"ubigint":                  {"uint64"},
+	"uhugeint":                 {"uint128"},
 	"decimal":                  {"numeric"},
 	"numeric":                  {"decimal"},
 	"timestamptz":              {"timestamp with time zone"},
 	"timestamp with time zone": {"timestamptz"},
+	"timestamp":                {"datetime", "timestamp without time zone"},
+	"datetime":                 {"timestamp"},
+	"timestamp_s":              {"timestamp"},
+	"timestamp_ms":             {"timestamp"},
+	"timestamp_ns":             {"timestamp"},
+	"timetz":                   {"time with time zone"},
+	"time with time zone":      {"timetz"},
 	"bool":                     {"boolean"},
 	"boolean":                  {"bool"},
 	"bit":                      {"bitstring"},
 	"char":                     {"character"},
-	"varchar":                  {"character varying"},
+	"varchar":                  {"character varying", "text"},
+	"text":                     {"varchar"},
 	"float4":                   {"real"},
 	"float8":                   {"double"},
+	"float":                    {"float4", "real"},
+	"real":                     {"float4", "float"},
+	"double":                   {"float8", "double precision"},
 	"blob":                     {"binary"},
+	"integer[]":                {"int[]", "int4[]"},
+	"bigint[]":                 {"int8[]"},
+	"varchar[]":                {"text[]", "string[]"},
+	"text[]":                   {"varchar[]"},
+	"double[]":                 {"float8[]"},
 }
 
 type Migrator struct {
@@ -61,13 +101,67 @@ type BuildIndexOptionsInterface interface {
 	BuildIndexOptions([]schema.IndexOption, *gorm.Statement) []interface{}
 }
 
+// DatabaseManager attaches other database files to the DuckDB instance, their tables are
+// then reachable as alias.table.
+type DatabaseManager interface {
+	AttachDatabase(path, alias string, readOnly bool) error
+	DetachDatabase(alias string) error
+	ListAttachedDatabases() ([]string, error)
+}
+
+var _ DatabaseManager = Migrator{}
+
 // Database
 
+// checkWritable returns ErrReadOnlyMode when the dialector opens the database read-only,
+// so write migrations fail with it rather than with DuckDB's error.
+func (m Migrator) checkWritable() error {
+	if dialector, ok := m.Dialector.(Dialector); ok && dialector.Config != nil {
+		if dialector.ReadOnly || strings.Contains(strings.ToLower(dialector.DSN), "access_mode=read_only") {
+			return ErrReadOnlyMode
+		}
+	}
+	return nil
+}
+
 func (m Migrator) CurrentDatabase() (name string) {
 	m.DB.Raw("SELECT CURRENT_DATABASE()").Scan(&name)
 	return
 }
 
+// AttachDatabase attaches the database file at path as alias, unless alias is already attached.
+func (m Migrator) AttachDatabase(path, alias string, readOnly bool) error {
+	if err := validIdentifier(alias); err != nil {
+		return err
+	}
+
+	attachSQL := "ATTACH IF NOT EXISTS ? AS " + alias
+	if readOnly {
+		attachSQL += " (READ_ONLY)"
+	}
+	return m.DB.Exec(attachSQL, stringLiteral(path)).Error
+}
+
+// DetachDatabase detaches the database attached as alias.
+func (m Migrator) DetachDatabase(alias string) error {
+	if err := validIdentifier(alias); err != nil {
+		return err
+	}
+	if err := m.DB.Exec("DETACH " + alias).Error; err != nil {
+		return err
+	}
+
+	m.resetPreparedStmts()
+	return nil
+}
+
+// ListAttachedDatabases returns the aliases of the attached databases, other than the current one.
+func (m Migrator) ListAttachedDatabases() (aliases []string, err error) {
+	return aliases, m.DB.Raw(
+		"SELECT database_name FROM duckdb_databases() WHERE NOT internal AND database_name <> current_database() ORDER BY database_name",
+	).Scan(&aliases).Error
+}
+
 func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
 	expr := m.Migrator.FullDataTypeOf(field)
 
@@ -84,28 +178,136 @@ func (m Migrator) GetTypeAliases(databaseTypeName string) []string {
 	return typeAliasMap[databaseTypeName]
 }
 
+// Schemas
+
+func (m Migrator) SetSchema(name string) error {
+	return SetSchema(m.DB, name)
+}
+
+func (m Migrator) GetCurrentSchema() (string, error) {
+	return GetCurrentSchema(m.DB)
+}
+
+// ListSchemas returns the schemas of the current database.
+func (m Migrator) ListSchemas() (schemas []string, err error) {
+	return schemas, m.DB.Raw(
+		"SELECT schema_name FROM information_schema.schemata WHERE catalog_name = current_database() ORDER BY schema_name",
+	).Scan(&schemas).Error
+}
+
+// HasSchema reports whether the current database has a schema named name.
+func (m Migrator) HasSchema(name string) bool {
+	var count int64
+	m.DB.Raw(
+		"SELECT count(*) FROM information_schema.schemata WHERE catalog_name = current_database() AND schema_name = ?", name,
+	).Row().Scan(&count)
+	return count > 0
+}
+
+// CreateSchema creates the schema name unless it exists. name must be a plain identifier.
+func (m Migrator) CreateSchema(name string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	if err := validIdentifier(name); err != nil {
+		return err
+	}
+	return m.DB.Exec("CREATE SCHEMA IF NOT EXISTS " + name).Error
+}
+
+// DropSchema drops the schema name if it exists, with its tables and other objects when cascade is set.
+// Sequences used by the defaults of its tables are dropped too, even when they live in another schema.
+// name must be a plain identifier.
+func (m Migrator) DropSchema(name string, cascade bool) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	if err := validIdentifier(name); err != nil {
+		return err
+	}
+
+	var sequences []string
+	if cascade {
+		var defaults []string
+		if err := m.DB.Raw(
+			"SELECT column_default FROM duckdb_columns() WHERE database_name = current_database() AND schema_name = ? AND column_default LIKE 'nextval(%'",
+			name,
+		).Scan(&defaults).Error; err != nil {
+			return err
+		}
+		for _, dflt := range defaults {
+			// sequences of the schema itself go with the CASCADE
+			if match := nextvalRegexp.FindStringSubmatch(dflt); match != nil && !strings.HasPrefix(match[1], name+".") {
+				sequences = append(sequences, match[1])
+			}
+		}
+	}
+
+	dropSQL := "DROP SCHEMA IF EXISTS " + name
+	if cascade {
+		dropSQL += " CASCADE"
+	}
+	if err := m.DB.Exec(dropSQL).Error; err != nil {
+		return err
+	}
+
+	// the sequences can only be dropped once the tables depending on them are gone
+	for _, sequence := range sequences {
+		if err := m.DB.Exec("DROP SEQUENCE IF EXISTS " + sequence).Error; err != nil {
+			return err
+		}
+	}
+
+	m.resetPreparedStmts()
+	return nil
+}
+
+func (m Migrator) GetTableSize(value interface{}) (TableSizeInfo, error) {
+	return GetTableSize(m.DB, value)
+}
+
 // Tables
 
+// sequenceFields returns the auto-increment primary key fields of stmt's schema, whose values
+// come from a {table}_{column}_seq sequence. Fields with a default of their own are skipped.
+func sequenceFields(stmt *gorm.Statement) []*schema.Field {
+	if stmt.Schema == nil {
+		return nil
+	}
+
+	var fields []*schema.Field
+	for _, field := range stmt.Schema.PrimaryFields {
+		if field.IgnoreMigration || !field.AutoIncrement || field.DefaultValue != "" {
+			continue
+		}
+		if field.GORMDataType == schema.Int || field.GORMDataType == schema.Uint {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// sequenceName returns the {table}_{column}_seq sequence of field, in the catalog and schema
+// of the table when the table name is qualified.
+func (m Migrator) sequenceName(stmt *gorm.Statement, field *schema.Field) string {
+	schemaName, table := m.CurrentSchema(stmt, stmt.Table)
+	name := fmt.Sprintf("%v_%s_seq", table, field.DBName)
+	if schemaName, ok := schemaName.(string); ok {
+		name = schemaName + "." + name
+		if catalog, ok := m.currentCatalog(stmt, stmt.Table).(string); ok {
+			name = catalog + "." + name
+		}
+	}
+	return name
+}
+
 func (m Migrator) createSequence(values ...interface{}) error {
 	for _, value := range m.ReorderModels(values, false) {
 		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-			if stmt.Schema != nil {
-				// Check if schema has an id field
-				hasIDField := false
-				for _, dbName := range stmt.Schema.DBNames {
-					if dbName == "id" {
-						hasIDField = true
-						break
-					}
-				}
-
-				if hasIDField {
-					tableName := m.CurrentTable(stmt).(clause.Table).Name
-					sequenceName := fmt.Sprintf("%s_id_seq", tableName)
-					if execErr := m.DB.Exec(
-						"CREATE SEQUENCE IF NOT EXISTS " + sequenceName + " START 1").Error; execErr != nil {
-						return execErr
-					}
+			for _, field := range sequenceFields(stmt) {
+				if execErr := m.DB.Exec(
+					"CREATE SEQUENCE IF NOT EXISTS " + m.sequenceName(stmt, field) + " START 1").Error; execErr != nil {
+					return execErr
 				}
 			}
 			return nil
@@ -117,9 +319,15 @@ func (m Migrator) createSequence(values ...interface{}) error {
 }
 
 func (m Migrator) CreateTable(values ...interface{}) (err error) {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
 	if err := m.createSequence(values...); err != nil {
 		return err
 	}
+	if err := m.createEnums(values...); err != nil {
+		return err
+	}
 
 	for _, value := range m.ReorderModels(values, false) {
 		tx := m.DB.Session(&gorm.Session{})
@@ -132,15 +340,17 @@ func (m Migrator) CreateTable(values ...interface{}) (err error) {
 				createTableSQL          = "CREATE TABLE ? ("
 				values                  = []interface{}{m.CurrentTable(stmt)}
 				hasPrimaryKeyInDataType bool
+				sequences               = map[*schema.Field]bool{}
 			)
+			for _, field := range sequenceFields(stmt) {
+				sequences[field] = true
+			}
 
 			for _, dbName := range stmt.Schema.DBNames {
 				field := stmt.Schema.FieldsByDBName[dbName]
 				if !field.IgnoreMigration {
-					if dbName == "id" {
-						tableName := m.CurrentTable(stmt).(clause.Table).Name
-						sequenceName := fmt.Sprintf("%s_id_seq", tableName)
-						pk := fmt.Sprintf("? ? DEFAULT nextval('%s')", sequenceName)
+					if sequences[field] {
+						pk := fmt.Sprintf("? ? DEFAULT nextval('%s')", m.sequenceName(stmt, field))
 						createTableSQL += pk
 
 					} else {
@@ -235,7 +445,7 @@ func (m Migrator) CreateTable(values ...interface{}) (err error) {
 					if field.Comment != "" {
 						if err := m.DB.Exec(
 							"COMMENT ON COLUMN ?.? IS ?",
-							m.CurrentTable(stmt), clause.Column{Name: field.DBName}, gorm.Expr(m.Migrator.Dialector.Explain("$1", field.Comment)),
+							m.CurrentTable(stmt), clause.Column{Name: field.DBName}, commentLiteral(field.Comment),
 						).Error; err != nil {
 							return err
 						}
@@ -251,11 +461,23 @@ func (m Migrator) CreateTable(values ...interface{}) (err error) {
 }
 
 func (m Migrator) DropTable(values ...interface{}) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
 	values = m.ReorderModels(values, false)
 	tx := m.DB.Session(&gorm.Session{})
 	for i := len(values) - 1; i >= 0; i-- {
 		if err := m.RunWithValue(values[i], func(stmt *gorm.Statement) error {
-			return tx.Exec("DROP TABLE IF EXISTS ? CASCADE", m.CurrentTable(stmt)).Error
+			if err := tx.Exec("DROP TABLE IF EXISTS ? CASCADE", m.CurrentTable(stmt)).Error; err != nil {
+				return err
+			}
+			// sequences are not dropped by CASCADE, drop the ones created by createSequence
+			for _, field := range sequenceFields(stmt) {
+				if err := tx.Exec("DROP SEQUENCE IF EXISTS " + m.sequenceName(stmt, field)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
 		}); err != nil {
 			return err
 		}
@@ -263,31 +485,202 @@ func (m Migrator) DropTable(values ...interface{}) error {
 	return nil
 }
 
+// TruncateTable deletes all rows of the tables and restarts their auto-increment sequences at 1.
+// DuckDB can neither restart a sequence nor drop one still referenced by a column default,
+// so tables with sequences are recreated from their DDL in duckdb_tables() with new sequences,
+// keeping indexes and column comments. Failures are wrapped in ErrTruncateTable.
+func (m Migrator) TruncateTable(values ...interface{}) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			err := m.DB.Transaction(func(tx *gorm.DB) error {
+				if fields := sequenceFields(stmt); len(fields) > 0 {
+					return m.recreateTable(tx, stmt, fields)
+				}
+				return tx.Exec("TRUNCATE TABLE ?", m.CurrentTable(stmt)).Error
+			})
+			if err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrTruncateTable, stmt.Table, err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	m.resetPreparedStmts()
+	return nil
+}
+
+// recreateTable drops stmt's table and creates it empty again from the DDL DuckDB keeps for it,
+// after recreating the sequences of fields.
+func (m Migrator) recreateTable(tx *gorm.DB, stmt *gorm.Statement, fields []*schema.Field) error {
+	currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+	catalog := m.currentCatalog(stmt, stmt.Table)
+	var tableSQL []string
+	if err := tx.Raw(
+		"SELECT sql FROM duckdb_tables() WHERE database_name = ? AND schema_name = ? AND table_name = ?",
+		catalog, currentSchema, curTable,
+	).Scan(&tableSQL).Error; err != nil {
+		return err
+	}
+	if len(tableSQL) == 0 {
+		return fmt.Errorf("table %v not found", curTable)
+	}
+
+	var indexSQL []string
+	if err := tx.Raw(
+		"SELECT sql FROM duckdb_indexes() WHERE database_name = ? AND schema_name = ? AND table_name = ? AND sql IS NOT NULL",
+		catalog, currentSchema, curTable,
+	).Scan(&indexSQL).Error; err != nil {
+		return err
+	}
+
+	var comments []struct {
+		ColumnName string
+		Comment    string
+	}
+	if err := tx.Raw(
+		"SELECT column_name, comment FROM duckdb_columns() WHERE database_name = ? AND schema_name = ? AND table_name = ? AND comment IS NOT NULL",
+		catalog, currentSchema, curTable,
+	).Scan(&comments).Error; err != nil {
+		return err
+	}
+
+	table := m.CurrentTable(stmt)
+	if err := tx.Exec("DROP TABLE ?", table).Error; err != nil {
+		return err
+	}
+	for _, field := range fields {
+		seq := m.sequenceName(stmt, field)
+		if err := tx.Exec("DROP SEQUENCE IF EXISTS " + seq).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("CREATE SEQUENCE " + seq + " START 1").Error; err != nil {
+			return err
+		}
+	}
+
+	for _, sql := range append(tableSQL, indexSQL...) {
+		if err := tx.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+	for _, comment := range comments {
+		if err := tx.Exec(
+			"COMMENT ON COLUMN ?.? IS ?", table, clause.Column{Name: comment.ColumnName}, stringLiteral(comment.Comment),
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CurrentSchema returns the schema and table of table, which may be qualified as schema.table,
+// "schema"."table" or catalog.schema.table, falling back to the qualified stmt.TableExpr
+// and then to CURRENT_SCHEMA().
 func (m Migrator) CurrentSchema(stmt *gorm.Statement, table string) (interface{}, interface{}) {
-	if strings.Contains(table, ".") {
-		if tables := strings.Split(table, `.`); len(tables) == 2 {
-			return tables[0], tables[1]
+	if parts, ok := splitQualifiedName(table); ok {
+		switch len(parts) {
+		case 2, 3:
+			return parts[len(parts)-2], parts[len(parts)-1]
+		case 1:
+			table = parts[0]
 		}
 	}
 
 	if stmt.TableExpr != nil {
-		if tables := strings.Split(stmt.TableExpr.SQL, `"."`); len(tables) == 2 {
-			return strings.TrimPrefix(tables[0], `"`), table
+		if parts, ok := splitQualifiedName(stmt.TableExpr.SQL); ok && (len(parts) == 2 || len(parts) == 3) {
+			return parts[len(parts)-2], parts[len(parts)-1]
 		}
 	}
 	return clause.Expr{SQL: "CURRENT_SCHEMA()"}, table
 }
 
+// currentCatalog returns the database of table to go with CurrentSchema, the catalog part of
+// catalog.schema.table or of the qualified stmt.TableExpr, and CURRENT_DATABASE() otherwise.
+func (m Migrator) currentCatalog(stmt *gorm.Statement, table string) interface{} {
+	if parts, ok := splitQualifiedName(table); ok && len(parts) > 1 {
+		if len(parts) == 3 {
+			return parts[0]
+		}
+		return clause.Expr{SQL: "CURRENT_DATABASE()"}
+	}
+
+	if stmt.TableExpr != nil {
+		if parts, ok := splitQualifiedName(stmt.TableExpr.SQL); ok && len(parts) == 3 {
+			return parts[0]
+		}
+	}
+	return clause.Expr{SQL: "CURRENT_DATABASE()"}
+}
+
+// splitQualifiedName splits a dotted name into its unquoted parts, where a part is either
+// a "double quoted" identifier, with "" escaping a quote, or a run of characters other than
+// dots, quotes and spaces. ok is false when name is anything else, e.g. a subquery.
+func splitQualifiedName(name string) (parts []string, ok bool) {
+	for i := 0; i < len(name); {
+		var part strings.Builder
+		if name[i] == '"' {
+			closed := false
+			for i++; i < len(name); i++ {
+				if name[i] == '"' {
+					if i+1 < len(name) && name[i+1] == '"' {
+						part.WriteByte('"')
+						i++
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				part.WriteByte(name[i])
+			}
+			if !closed {
+				return nil, false
+			}
+		} else {
+			for ; i < len(name) && name[i] != '.'; i++ {
+				if name[i] == '"' || name[i] == ' ' || name[i] == '\t' || name[i] == '\n' {
+					return nil, false
+				}
+				part.WriteByte(name[i])
+			}
+		}
+
+		if part.Len() == 0 {
+			return nil, false
+		}
+		parts = append(parts, part.String())
+
+		if i < len(name) {
+			if name[i] != '.' || i == len(name)-1 {
+				return nil, false
+			}
+			i++
+		}
+	}
+	return parts, len(parts) > 0
+}
+
 func (m Migrator) HasTable(value interface{}) bool {
 	var count int64
 	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
-		return m.DB.Raw("SELECT count(*) FROM information_schema.tables WHERE table_schema = ? AND table_name = ? AND table_type = ?", currentSchema, curTable, "BASE TABLE").Scan(&count).Error
+		return m.DB.Raw(
+			"SELECT count(*) FROM information_schema.tables WHERE table_catalog = ? AND table_schema = ? AND table_name = ? AND table_type = ?",
+			m.currentCatalog(stmt, stmt.Table), currentSchema, curTable, "BASE TABLE",
+		).Scan(&count).Error
 	})
 	return count > 0
 }
 
 func (m Migrator) RenameTable(oldName, newName interface{}) (err error) {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
 	resolveTable := func(name interface{}) (result string, err error) {
 		if v, ok := name.(string); ok {
 			result = v
@@ -322,11 +715,32 @@ func (m Migrator) RenameTable(oldName, newName interface{}) (err error) {
 
 func (m Migrator) GetTables() (tableList []string, err error) {
 	currentSchema, _ := m.CurrentSchema(m.DB.Statement, "")
-	return tableList, m.DB.Raw("SELECT table_name FROM information_schema.tables WHERE table_schema = ? AND table_type = ?", currentSchema, "BASE TABLE").Scan(&tableList).Error
+	return m.getTables(clause.Expr{SQL: "current_database()"}, currentSchema, "BASE TABLE")
+}
+
+// GetTablesInSchema returns the tables of schemaName in the current database, or of an attached
+// database when qualified as catalog.schema, e.g. other.main after ATTACH 'other.db' AS other.
+func (m Migrator) GetTablesInSchema(schemaName string) ([]string, error) {
+	var catalog interface{} = clause.Expr{SQL: "current_database()"}
+	if parts, ok := splitQualifiedName(schemaName); ok && len(parts) == 2 {
+		catalog, schemaName = parts[0], parts[1]
+	}
+	return m.getTables(catalog, schemaName, "BASE TABLE")
+}
+
+// getTables lists the relations of tableType, BASE TABLE or VIEW, in the schema.
+func (m Migrator) getTables(catalog, schemaName interface{}, tableType string) (tableList []string, err error) {
+	return tableList, m.DB.Raw(
+		"SELECT table_name FROM information_schema.tables WHERE table_catalog = ? AND table_schema = ? AND table_type = ? ORDER BY table_name",
+		catalog, schemaName, tableType,
+	).Scan(&tableList).Error
 }
 
 // Columns
 func (m Migrator) DropColumn(dst interface{}, field string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
 	if err := m.Migrator.DropColumn(dst, field); err != nil {
 		return err
 	}
@@ -345,37 +759,65 @@ func (m Migrator) resetPreparedStmts() {
 	}
 }
 
-func (m Migrator) MigrateColumn(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+func (m Migrator) MigrateColumn(value interface{}, field *schema.Field, column gorm.ColumnType) error {
+	// gorm compares the decimal size against "decimal(p,s)", but DataTypeOf writes "numeric(p, s)",
+	// or a bare "decimal" that DuckDB creates as DECIMAL(18,3), so hide a size matching the field
+	if ct, ok := column.(columnType); ok && ct.DecimalSizeValue.Valid {
+		precision, scale := int64(field.Precision), int64(field.Scale)
+		if precision == 0 {
+			precision, scale = 18, 3
+		}
+		if ct.DecimalSizeValue.Int64 == precision && ct.ScaleValue.Int64 == scale {
+			ct.DecimalSizeValue, ct.ScaleValue = sql.NullInt64{}, sql.NullInt64{}
+			column = ct
+		}
+	}
+
 	// skip primary field and unique fields as DuckDB doesn't support altering column types with constraints
 	if !field.PrimaryKey && !field.Unique {
-		if err := m.Migrator.MigrateColumn(value, field, columnType); err != nil {
+		if err := m.Migrator.MigrateColumn(value, field, column); err != nil {
 			return err
 		}
 	}
 
+	if nullable, ok := column.Nullable(); ok && !field.PrimaryKey {
+		if nullable && field.NotNull {
+			if err := m.SetNotNull(value, field.DBName); err != nil {
+				return err
+			}
+		} else if !nullable && !field.NotNull {
+			if err := m.DropNotNull(value, field.DBName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if field.Comment == "" {
+		return nil
+	}
+
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		var description sql.NullString
+		// duckdb_columns() rather than pg_catalog.pg_description, which DuckDB only partially emulates;
+		// a missing row means no comment
+		var descriptions []sql.NullString
 		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
-		values := []interface{}{currentSchema, curTable, field.DBName, stmt.Table, currentSchema}
-		checkSQL := "SELECT description FROM pg_catalog.pg_description "
-		checkSQL += "WHERE objsubid IN (SELECT ordinal_position FROM information_schema.columns WHERE table_schema = ? AND table_name = ? AND column_name = ?) "
-		checkSQL += "AND objoid IN (SELECT oid FROM pg_catalog.pg_class WHERE relname = ? AND relnamespace IN "
-		checkSQL += "(SELECT oid FROM pg_catalog.pg_namespace WHERE nspname = ?))"
-		if err := m.DB.Raw(checkSQL, values...).Row().Scan(&description); err != nil {
+		if err := m.DB.Raw(
+			"SELECT comment FROM duckdb_columns() WHERE database_name = ? AND schema_name = ? AND table_name = ? AND column_name = ?",
+			m.currentCatalog(stmt, stmt.Table), currentSchema, curTable, field.DBName,
+		).Scan(&descriptions).Error; err != nil {
 			return err
 		}
+		var description sql.NullString
+		if len(descriptions) > 0 {
+			description = descriptions[0]
+		}
 
 		comment := strings.Trim(field.Comment, "'")
 		comment = strings.Trim(comment, `"`)
-		if field.Comment != "" && comment != description.String {
+		if comment != description.String {
 			if err := m.DB.Exec(
 				"COMMENT ON COLUMN ?.? IS ?",
-				m.CurrentTable(stmt), clause.Column{Name: field.DBName}, gorm.Expr(func() string {
-					if dialector, ok := m.DB.Dialector.(Dialector); ok {
-						return dialector.Explain("$1", field.Comment)
-					}
-					return "?"
-				}()),
+				m.CurrentTable(stmt), clause.Column{Name: field.DBName}, commentLiteral(field.Comment),
 			).Error; err != nil {
 				return err
 			}
@@ -384,6 +826,212 @@ func (m Migrator) MigrateColumn(value interface{}, field *schema.Field, columnTy
 	})
 }
 
+// AddColumn adds field's column with its type and default, then sets NOT NULL and the comment
+// separately, as DuckDB doesn't accept constraints in ALTER TABLE ... ADD COLUMN.
+// Defaults that are SQL expressions, e.g. current_timestamp or nextval('seq'), are written verbatim.
+func (m Migrator) AddColumn(value interface{}, name string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return errors.New("failed to get schema")
+		}
+		f := stmt.Schema.LookUpField(name)
+		if f == nil {
+			return fmt.Errorf("failed to look up field with name: %s", name)
+		}
+		if f.IgnoreMigration {
+			return nil
+		}
+
+		columnSQL := m.DataTypeOf(f)
+		if defaultSQL, ok := m.defaultValueOf(f); ok {
+			columnSQL += " DEFAULT " + defaultSQL
+		}
+		if err := m.DB.Exec(
+			"ALTER TABLE ? ADD COLUMN ? ?", m.CurrentTable(stmt), clause.Column{Name: f.DBName}, clause.Expr{SQL: columnSQL},
+		).Error; err != nil {
+			return err
+		}
+
+		if f.NotNull {
+			if err := m.DB.Exec("ALTER TABLE ? ALTER COLUMN ? SET NOT NULL", m.CurrentTable(stmt), clause.Column{Name: f.DBName}).Error; err != nil {
+				return err
+			}
+		}
+		if f.Comment != "" {
+			return m.DB.Exec(
+				"COMMENT ON COLUMN ?.? IS ?",
+				m.CurrentTable(stmt), clause.Column{Name: f.DBName}, commentLiteral(f.Comment),
+			).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.resetPreparedStmts()
+	return nil
+}
+
+// AlterColumn changes the type and the default of field's column with DuckDB's
+// ALTER COLUMN ... SET DATA TYPE and SET DEFAULT / DROP DEFAULT, each only when it differs
+// from the current column. The nextval default of auto-increment primary keys is kept.
+func (m Migrator) AlterColumn(value interface{}, field string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return fmt.Errorf("failed to look up field with name: %s", field)
+		}
+		f := stmt.Schema.LookUpField(field)
+		if f == nil {
+			return fmt.Errorf("failed to look up field with name: %s", field)
+		}
+
+		column, found := m.currentColumn(value, f.DBName)
+		if !found || !m.sameDataType(f, column) {
+			if err := m.DB.Exec(
+				"ALTER TABLE ? ALTER COLUMN ? SET DATA TYPE ?",
+				m.CurrentTable(stmt), clause.Column{Name: f.DBName}, clause.Expr{SQL: m.DataTypeOf(f)},
+			).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, sequence := range sequenceFields(stmt) {
+			if sequence == f {
+				return nil
+			}
+		}
+
+		defaultSQL, hasDefault := m.defaultValueOf(f)
+		currentDefault, hasCurrentDefault := "", false
+		if found {
+			currentDefault, hasCurrentDefault = column.DefaultValue()
+		}
+		switch {
+		case hasDefault && (!hasCurrentDefault || currentDefault != f.DefaultValue):
+			return m.DB.Exec(
+				"ALTER TABLE ? ALTER COLUMN ? SET DEFAULT ?",
+				m.CurrentTable(stmt), clause.Column{Name: f.DBName}, clause.Expr{SQL: defaultSQL},
+			).Error
+		case !hasDefault && (!found || hasCurrentDefault):
+			return m.DB.Exec(
+				"ALTER TABLE ? ALTER COLUMN ? DROP DEFAULT",
+				m.CurrentTable(stmt), clause.Column{Name: f.DBName},
+			).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.resetPreparedStmts()
+	return nil
+}
+
+var defaultKeywords = map[string]bool{
+	"current_timestamp": true, "current_date": true, "current_time": true,
+	"localtimestamp": true, "localtime": true, "now": true,
+}
+
+// isDefaultExpression reports whether a default tag value is a SQL expression rather than a constant.
+func isDefaultExpression(value string) bool {
+	return strings.Contains(value, "(") || defaultKeywords[strings.ToLower(value)]
+}
+
+// currentColumn returns the column named name of value's table.
+func (m Migrator) currentColumn(value interface{}, name string) (gorm.ColumnType, bool) {
+	columnTypes, err := m.ColumnTypes(value)
+	if err != nil {
+		return nil, false
+	}
+	for _, columnType := range columnTypes {
+		if columnType.Name() == name {
+			return columnType, true
+		}
+	}
+	return nil, false
+}
+
+// sameDataType reports whether column already has field's type, comparing type names like
+// MigrateColumn does and the precision and scale of decimals.
+func (m Migrator) sameDataType(field *schema.Field, column gorm.ColumnType) bool {
+	dataType := strings.ToLower(m.DataTypeOf(field))
+	realDataType := strings.ToLower(column.DatabaseTypeName())
+
+	same := strings.HasPrefix(dataType, realDataType)
+	for _, alias := range m.GetTypeAliases(realDataType) {
+		same = same || strings.HasPrefix(dataType, alias)
+	}
+
+	if precision, scale, ok := column.DecimalSize(); same && ok && field.Precision > 0 {
+		same = precision == int64(field.Precision) && scale == int64(field.Scale)
+	}
+	return same
+}
+
+// defaultValueOf returns the DEFAULT expression of field, if any. Values are written like
+// FullDataTypeOf does, SQL expressions such as current_timestamp or nextval('seq') verbatim.
+func (m Migrator) defaultValueOf(field *schema.Field) (string, bool) {
+	if !field.HasDefaultValue || (field.DefaultValueInterface == nil && field.DefaultValue == "") {
+		return "", false
+	}
+	if isDefaultExpression(field.DefaultValue) {
+		return field.DefaultValue, true
+	}
+	if field.DefaultValueInterface != nil {
+		defaultStmt := &gorm.Statement{Vars: []interface{}{field.DefaultValueInterface}}
+		m.Dialector.BindVarTo(defaultStmt, defaultStmt, field.DefaultValueInterface)
+		return m.Dialector.Explain(defaultStmt.SQL.String(), field.DefaultValueInterface), true
+	}
+	if field.DefaultValue == "(-)" {
+		return "", false
+	}
+	return field.DefaultValue, true
+}
+
+// commentLiteral renders the comment of a field as a SQL string, without the quotes it may be tagged with.
+func commentLiteral(comment string) clause.Expr {
+	return stringLiteral(strings.Trim(strings.Trim(comment, "'"), `"`))
+}
+
+// SetNotNull adds a NOT NULL constraint to field's column, failing when it holds NULLs.
+func (m Migrator) SetNotNull(dst interface{}, field string) error {
+	return m.alterNotNull(dst, field, "SET NOT NULL")
+}
+
+// DropNotNull removes the NOT NULL constraint of field's column.
+func (m Migrator) DropNotNull(dst interface{}, field string) error {
+	return m.alterNotNull(dst, field, "DROP NOT NULL")
+}
+
+func (m Migrator) alterNotNull(dst interface{}, field, action string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	err := m.RunWithValue(dst, func(stmt *gorm.Statement) error {
+		name := field
+		if stmt.Schema != nil {
+			if f := stmt.Schema.LookUpField(field); f != nil {
+				name = f.DBName
+			}
+		}
+		return m.DB.Exec("ALTER TABLE ? ALTER COLUMN ? "+action, m.CurrentTable(stmt), clause.Column{Name: name}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	m.resetPreparedStmts()
+	return nil
+}
+
 func (m Migrator) HasColumn(value interface{}, field string) bool {
 	var count int64
 	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
@@ -396,8 +1044,8 @@ func (m Migrator) HasColumn(value interface{}, field string) bool {
 
 		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
 		return m.DB.Raw(
-			"SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_schema = ? AND table_name = ? AND column_name = ?",
-			currentSchema, curTable, name,
+			"SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_catalog = ? AND table_schema = ? AND table_name = ? AND column_name = ?",
+			m.currentCatalog(stmt, stmt.Table), currentSchema, curTable, name,
 		).Scan(&count).Error
 	})
 
@@ -405,6 +1053,9 @@ func (m Migrator) HasColumn(value interface{}, field string) bool {
 }
 
 func (m Migrator) RenameColumn(dst interface{}, oldName, field string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
 	if err := m.Migrator.RenameColumn(dst, oldName, field); err != nil {
 		return err
 	}
@@ -413,8 +1064,274 @@ func (m Migrator) RenameColumn(dst interface{}, oldName, field string) error {
 	return nil
 }
 
-// TODO: Implement below function.
-// func (m Migrator) ColumnTypes(value interface{}) (columnTypes []gorm.ColumnType, err error)
+// ColumnTypes returns the columns of value's table as reported by duckdb_columns(),
+// each implementing DuckDBColumnType with the storage details of pragma_storage_info.
+func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
+	columnTypes := make([]gorm.ColumnType, 0)
+	execErr := m.RunWithValue(value, func(stmt *gorm.Statement) (err error) {
+		rows, err := m.DB.Session(&gorm.Session{}).Table(stmt.Table).Limit(1).Rows()
+		if err != nil {
+			return err
+		}
+		rawColumnTypes, err := rows.ColumnTypes()
+		if closeErr := rows.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		sqlColumnTypes := make(map[string]*sql.ColumnType, len(rawColumnTypes))
+		for _, c := range rawColumnTypes {
+			sqlColumnTypes[c.Name()] = c
+		}
+
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		catalog := m.currentCatalog(stmt, stmt.Table)
+		columns, err := m.DB.Raw(
+			"SELECT column_name, data_type, is_nullable, character_maximum_length, numeric_precision, numeric_scale, column_default, comment "+
+				"FROM duckdb_columns() WHERE database_name = ? AND schema_name = ? AND table_name = ? ORDER BY column_index",
+			catalog, currentSchema, curTable,
+		).Rows()
+		if err != nil {
+			return err
+		}
+		defer columns.Close()
+
+		storage, err := m.columnStorage(catalog, currentSchema, curTable)
+		if err != nil {
+			return err
+		}
+
+		keys, err := m.columnKeys(catalog, currentSchema, curTable)
+		if err != nil {
+			return err
+		}
+
+		for columns.Next() {
+			var (
+				column    columnType
+				dataType  string
+				nullable  bool
+				length    sql.NullInt64
+				precision sql.NullInt64
+				scale     sql.NullInt64
+				dflt      sql.NullString
+			)
+			if err := columns.Scan(&column.NameValue, &dataType, &nullable, &length, &precision, &scale, &dflt, &column.CommentValue); err != nil {
+				return err
+			}
+
+			column.SQLColumnType = sqlColumnTypes[column.NameValue.String]
+			if column.SQLColumnType != nil {
+				column.ScanTypeValue = column.SQLColumnType.ScanType()
+			}
+			column.NullableValue = sql.NullBool{Bool: nullable, Valid: true}
+			column.ColumnTypeValue = sql.NullString{String: dataType, Valid: true}
+			column.DataTypeValue = sql.NullString{String: dataType, Valid: true}
+			if idx := strings.IndexByte(dataType, '('); idx > 0 {
+				column.DataTypeValue.String = dataType[:idx]
+			}
+			if length.Valid {
+				column.LengthValue = length
+			}
+			if column.DataTypeValue.String == "DECIMAL" {
+				column.DecimalSizeValue = precision
+				column.ScaleValue = scale
+			}
+			if dflt.Valid {
+				column.AutoIncrementValue = sql.NullBool{Bool: strings.HasPrefix(strings.ToLower(dflt.String), "nextval("), Valid: true}
+				column.DefaultValueValue = sql.NullString{String: normalizeDefault(dflt.String), Valid: true}
+			} else {
+				column.AutoIncrementValue = sql.NullBool{Valid: true}
+			}
+
+			key := keys[column.NameValue.String]
+			column.PrimaryKeyValue = sql.NullBool{Bool: key.primaryKey, Valid: true}
+			column.UniqueValue = sql.NullBool{Bool: key.unique, Valid: true}
+
+			if info, ok := storage[column.NameValue.String]; ok {
+				column.storageSize = info.storageSize
+				column.compressionType = info.compressionType
+				column.hasStatistics = info.hasStatistics
+			}
+			columnTypes = append(columnTypes, column)
+		}
+		return columns.Err()
+	})
+
+	return columnTypes, execErr
+}
+
+type columnKey struct {
+	primaryKey bool
+	unique     bool
+}
+
+// columnKeys returns the columns of the table's primary key and of its single column UNIQUE constraints.
+func (m Migrator) columnKeys(catalog, currentSchema, table interface{}) (map[string]columnKey, error) {
+	rows, err := m.DB.Raw(
+		"SELECT constraint_type, constraint_column_names FROM duckdb_constraints() "+
+			"WHERE database_name = ? AND schema_name = ? AND table_name = ? AND constraint_type IN ('PRIMARY KEY', 'UNIQUE')",
+		catalog, currentSchema, table,
+	).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := map[string]columnKey{}
+	for rows.Next() {
+		var (
+			constraintType string
+			columnNames    []interface{}
+		)
+		if err := rows.Scan(&constraintType, &columnNames); err != nil {
+			return nil, err
+		}
+		for _, name := range columnNames {
+			column := fmt.Sprint(name)
+			key := keys[column]
+			if constraintType == "PRIMARY KEY" {
+				key.primaryKey = true
+			} else if len(columnNames) == 1 {
+				key.unique = true
+			}
+			keys[column] = key
+		}
+	}
+	return keys, rows.Err()
+}
+
+// normalizeDefault turns a string literal default like 'none' into its value, unescaping doubled
+// quotes, and the get_current_timestamp() DuckDB parses CURRENT_TIMESTAMP into back, as gorm
+// compares defaults against the unquoted value of the default tag.
+func normalizeDefault(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	if strings.EqualFold(value, "get_current_timestamp()") {
+		return "current_timestamp"
+	}
+	return value
+}
+
+// storageName returns the name pragma_storage_info looks table up by, qualified with the
+// schema and catalog resolved by CurrentSchema and currentCatalog when they were named.
+func storageName(catalog, currentSchema, table interface{}) string {
+	name := fmt.Sprint(table)
+	if schemaName, ok := currentSchema.(string); ok {
+		name = schemaName + "." + name
+		if catalogName, ok := catalog.(string); ok {
+			name = catalogName + "." + name
+		}
+	}
+	return name
+}
+
+// columnStorage summarizes pragma_storage_info per column. The size counts the storage
+// blocks of checkpointed segments, so data still in the WAL is not included.
+func (m Migrator) columnStorage(catalog, currentSchema, table interface{}) (map[string]columnType, error) {
+	name := storageName(catalog, currentSchema, table)
+
+	var blockSize int64
+	if err := m.DB.Raw("SELECT block_size FROM pragma_database_size() WHERE database_name = ?", catalog).Row().Scan(&blockSize); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.DB.Raw(
+		"SELECT column_name, count(DISTINCT block_id) FILTER (WHERE persistent AND block_id >= 0), "+
+			"coalesce(string_agg(DISTINCT compression, ',' ORDER BY compression) FILTER (WHERE column_path = '[' || column_id || ']'), ''), "+
+			"coalesce(bool_or(stats IS NOT NULL AND stats <> ''), false) "+
+			"FROM pragma_storage_info(?) GROUP BY column_name",
+		stringLiteral(name),
+	).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	storage := map[string]columnType{}
+	for rows.Next() {
+		var (
+			column string
+			blocks int64
+			info   columnType
+		)
+		if err := rows.Scan(&column, &blocks, &info.compressionType, &info.hasStatistics); err != nil {
+			return nil, err
+		}
+		info.storageSize = blocks * blockSize
+		storage[column] = info
+	}
+	return storage, rows.Err()
+}
+
+// Enums
+
+// createEnums creates the ENUM types of the fields tagged type:enum('a','b') that don't exist yet.
+func (m Migrator) createEnums(values ...interface{}) error {
+	for _, value := range m.ReorderModels(values, false) {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if stmt.Schema == nil {
+				return nil
+			}
+			for _, field := range stmt.Schema.Fields {
+				enumValues, ok := parseEnumValues(string(field.DataType))
+				if !ok || field.IgnoreMigration {
+					continue
+				}
+				name := enumTypeName(field)
+				if m.HasEnum(name) {
+					continue
+				}
+				if err := m.CreateEnum(name, enumValues); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateEnum runs CREATE TYPE name AS ENUM (values...).
+func (m Migrator) CreateEnum(name string, values []string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	if err := validIdentifier(name); err != nil {
+		return err
+	}
+	// the values are quoted into the statement once, DuckDB doesn't take parameters in CREATE TYPE
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = stringLiteral(value).SQL
+	}
+	return m.DB.Exec("CREATE TYPE " + name + " AS ENUM (" + strings.Join(literals, ", ") + ")").Error
+}
+
+// DropEnum drops the ENUM type name if it exists.
+func (m Migrator) DropEnum(name string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	if err := validIdentifier(name); err != nil {
+		return err
+	}
+	return m.DB.Exec("DROP TYPE IF EXISTS " + name).Error
+}
+
+// HasEnum reports whether the ENUM type name exists in the current schema.
+func (m Migrator) HasEnum(name string) bool {
+	var count int64
+	m.DB.Raw(
+		"SELECT count(*) FROM duckdb_types() WHERE database_name = current_database() AND schema_name = current_schema() AND type_name = ? AND logical_type = 'ENUM'",
+		name,
+	).Scan(&count)
+	return count > 0
+}
 
 // Views
 func (m Migrator) CreateView(name string, option gorm.ViewOption) error {
@@ -425,6 +1342,114 @@ func (m Migrator) DropView(name string) error {
 	return ErrDuckDBNotSupported
 }
 
+// GetViews returns the views of the current schema.
+func (m Migrator) GetViews() ([]string, error) {
+	currentSchema, _ := m.CurrentSchema(m.DB.Statement, "")
+	return m.getTables(clause.Expr{SQL: "current_database()"}, currentSchema, "VIEW")
+}
+
+// HasView reports whether the view name exists, in the current schema unless qualified as schema.view.
+func (m Migrator) HasView(name string) bool {
+	var count int64
+	currentSchema, view := m.CurrentSchema(m.DB.Statement, name)
+	m.DB.Raw(
+		"SELECT count(*) FROM information_schema.tables WHERE table_catalog = ? AND table_schema = ? AND table_name = ? AND table_type = ?",
+		m.currentCatalog(m.DB.Statement, name), currentSchema, view, "VIEW",
+	).Scan(&count)
+	return count > 0
+}
+
+// CreateMaterializedView stores the result of query as the table name. DuckDB has no
+// materialized views, so this is CREATE TABLE name AS (query), kept up to date with RefreshMaterializedView.
+func (m Migrator) CreateMaterializedView(name string, query string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	return m.DB.Exec("CREATE TABLE ? AS ("+query+")", clause.Table{Name: name}).Error
+}
+
+// RefreshMaterializedView recreates the table name from query in a transaction,
+// readers see either the old or the new result.
+func (m Migrator) RefreshMaterializedView(name string, query string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	err := m.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DROP TABLE IF EXISTS ?", clause.Table{Name: name}).Error; err != nil {
+			return err
+		}
+		return tx.Exec("CREATE TABLE ? AS ("+query+")", clause.Table{Name: name}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	m.resetPreparedStmts()
+	return nil
+}
+
+// Macros
+
+// CreateMacro creates or replaces the macro name(params...). A scalar macro returns the
+// expression body, a table macro, with isMacroTable, the rows of the query body.
+func (m Migrator) CreateMacro(name string, params []string, body string, isMacroTable bool) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	for _, identifier := range append([]string{name}, params...) {
+		if err := validIdentifier(identifier); err != nil {
+			return err
+		}
+	}
+
+	sql := "CREATE OR REPLACE MACRO " + name + "(" + strings.Join(params, ", ") + ") AS "
+	if isMacroTable {
+		sql += "TABLE " + body
+	} else {
+		sql += "(" + body + ")"
+	}
+	return m.DB.Exec(sql).Error
+}
+
+// DropMacro drops the scalar or table macro name if it exists.
+func (m Migrator) DropMacro(name string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	if err := validIdentifier(name); err != nil {
+		return err
+	}
+
+	var macroTypes []string
+	if err := m.DB.Raw(
+		"SELECT DISTINCT function_type FROM duckdb_functions() WHERE database_name = current_database() AND schema_name = current_schema() AND function_name = ? AND function_type IN ('macro', 'table_macro')",
+		name,
+	).Scan(&macroTypes).Error; err != nil {
+		return err
+	}
+
+	for _, macroType := range macroTypes {
+		sql := "DROP MACRO IF EXISTS "
+		if macroType == "table_macro" {
+			sql = "DROP MACRO TABLE IF EXISTS "
+		}
+		if err := m.DB.Exec(sql + name).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasMacro reports whether the scalar or table macro name exists in the current schema.
+func (m Migrator) HasMacro(name string) bool {
+	var count int64
+	m.DB.Raw(
+		"SELECT count(*) FROM duckdb_functions() WHERE database_name = current_database() AND schema_name = current_schema() AND function_name = ? AND function_type IN ('macro', 'table_macro')",
+		name,
+	).Scan(&count)
+	return count > 0
+}
+
 // Constraints
 
 // WARNING: Constraints have a strong impact on performance:
@@ -441,8 +1466,8 @@ func (m Migrator) HasConstraint(value interface{}, name string) bool {
 		currentSchema, curTable := m.CurrentSchema(stmt, table)
 
 		return m.DB.Raw(
-			"SELECT count(*) FROM INFORMATION_SCHEMA.table_constraints WHERE table_schema = ? AND table_name = ? AND constraint_name = ?",
-			currentSchema, curTable, name,
+			"SELECT count(*) FROM INFORMATION_SCHEMA.table_constraints WHERE table_catalog = ? AND table_schema = ? AND table_name = ? AND constraint_name = ?",
+			m.currentCatalog(stmt, table), currentSchema, curTable, name,
 		).Scan(&count).Error
 	})
 
@@ -460,6 +1485,9 @@ func (m Migrator) DropConstraint(dst interface{}, name string) error {
 // Indexes
 
 func (m Migrator) CreateIndex(value interface{}, name string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema != nil {
 			if idx := stmt.Schema.LookIndex(name); idx != nil {
@@ -495,6 +1523,9 @@ func (m Migrator) CreateIndex(value interface{}, name string) error {
 }
 
 func (m Migrator) DropIndex(value interface{}, name string) error {
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema != nil {
 			if idx := stmt.Schema.LookIndex(name); idx != nil {
@@ -506,8 +1537,55 @@ func (m Migrator) DropIndex(value interface{}, name string) error {
 	})
 }
 
+// RenameIndex recreates the index under newName, as DuckDB has no ALTER INDEX ... RENAME:
+// the definition is read from duckdb_indexes(), then the index is dropped and created again.
 func (m Migrator) RenameIndex(dst interface{}, oldName, newName string) error {
-	return ErrDuckDBNotSupported
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+	if err := validIdentifier(newName); err != nil {
+		return err
+	}
+
+	err := m.RunWithValue(dst, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if idx := stmt.Schema.LookIndex(oldName); idx != nil {
+				oldName = idx.Name
+			}
+		}
+
+		var definition sql.NullString
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		if err := m.DB.Raw(
+			"SELECT sql FROM duckdb_indexes() WHERE database_name = ? AND schema_name = ? AND table_name = ? AND index_name = ?",
+			m.currentCatalog(stmt, stmt.Table), currentSchema, curTable, oldName,
+		).Scan(&definition).Error; err != nil {
+			return err
+		}
+		if !definition.Valid {
+			return fmt.Errorf("%w: %s", ErrIndexNotFound, oldName)
+		}
+
+		nameRegexp := regexp.MustCompile(`(?is)^(\s*CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?)(?:"` +
+			regexp.QuoteMeta(strings.ReplaceAll(oldName, `"`, `""`)) + `"|` + regexp.QuoteMeta(oldName) + `)(\s)`)
+		if !nameRegexp.MatchString(definition.String) {
+			return fmt.Errorf("%w: unexpected definition of %s: %s", ErrDuckDBNotSupported, oldName, definition.String)
+		}
+		createSQL := nameRegexp.ReplaceAllString(definition.String, "${1}"+newName+"${2}")
+
+		return m.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS ?", clause.Column{Name: oldName}).Error; err != nil {
+				return err
+			}
+			return tx.Exec(createSQL).Error
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	m.resetPreparedStmts()
+	return nil
 }
 
 func (m Migrator) HasIndex(value interface{}, name string) bool {
@@ -520,9 +1598,93 @@ func (m Migrator) HasIndex(value interface{}, name string) bool {
 		}
 		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
 		return m.DB.Raw(
-			"SELECT count(*) FROM pg_indexes WHERE tablename = ? AND indexname = ? AND schemaname = ?", curTable, name, currentSchema,
+			"SELECT count(*) FROM duckdb_indexes() WHERE database_name = ? AND schema_name = ? AND table_name = ? AND index_name = ?",
+			m.currentCatalog(stmt, stmt.Table), currentSchema, curTable, name,
 		).Scan(&count).Error
 	})
 
 	return count > 0
 }
+
+// Engine
+
+// GetVersion returns the version of the DuckDB library, as reported by SELECT version(), e.g. v1.1.3.
+func (m Migrator) GetVersion() (version string, err error) {
+	return version, m.DB.Raw("SELECT version()").Row().Scan(&version)
+}
+
+// GetDuckDBVersion returns the version of the DuckDB library as numbers, for comparisons.
+func (m Migrator) GetDuckDBVersion() (major, minor, patch int, err error) {
+	version, err := m.GetVersion()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return parseVersion(version)
+}
+
+// Settings
+
+// GetSettings returns the current value of every setting listed by duckdb_settings(),
+// settings without a value map to the empty string.
+func (m Migrator) GetSettings() (map[string]string, error) {
+	var rows []struct {
+		Name  string
+		Value sql.NullString
+	}
+	if err := m.DB.Raw("SELECT name, value FROM duckdb_settings()").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]string, len(rows))
+	for _, row := range rows {
+		settings[row.Name] = row.Value.String
+	}
+	return settings, nil
+}
+
+// GetSetting returns the current value of the setting name, as displayed by DuckDB, e.g. 2 for threads.
+// Names not listed by duckdb_settings() are reported with ErrUnknownSetting.
+func (m Migrator) GetSetting(name string) (string, error) {
+	var values []sql.NullString
+	if err := m.DB.Raw("SELECT value FROM duckdb_settings() WHERE name = ?", name).Scan(&values).Error; err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrUnknownSetting, name)
+	}
+	return values[0].String, nil
+}
+
+// SetSetting changes the setting name with SET name = 'value', DuckDB converts value to the type
+// of the setting, e.g. SetSetting("max_memory", "2GB"). Names not listed by duckdb_settings(),
+// aliases such as memory_limit included, are reported with ErrUnknownSetting instead of failing in DuckDB.
+func (m Migrator) SetSetting(name, value string) error {
+	if err := m.checkSetting(name); err != nil {
+		return err
+	}
+	return m.DB.Exec("SET " + name + " = " + stringLiteral(value).SQL).Error
+}
+
+// ResetSetting restores the default value of the setting name with RESET name.
+func (m Migrator) ResetSetting(name string) error {
+	if err := m.checkSetting(name); err != nil {
+		return err
+	}
+	return m.DB.Exec("RESET " + name).Error
+}
+
+// checkSetting returns ErrUnknownSetting unless name is listed by duckdb_settings().
+func (m Migrator) checkSetting(name string) error {
+	if err := validIdentifier(name); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownSetting, name)
+	}
+
+	var count int64
+	if err := m.DB.Raw("SELECT count(*) FROM duckdb_settings() WHERE name = ?", name).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownSetting, name)
+	}
+	return nil
+}
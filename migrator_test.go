@@ -18,12 +18,14 @@
 package duckdb_test
 
 import (
+	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
-	_ "github.com/marcboeker/go-duckdb/v2"
+	goduckdb "github.com/marcboeker/go-duckdb/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/vogo/duckdb/v2"
 	"gorm.io/gorm"
@@ -111,6 +113,266 @@ func TestMigratorDropTable(t *testing.T) {
 	assert.False(t, db.Migrator().HasTable(&User{}))
 }
 
+// TestDropTableSequence verifies the id sequence is dropped with its table and ids restart after recreating it.
+func TestDropTableSequence(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	countSequences := func() (count int64) {
+		assert.NoError(t, db.Raw("SELECT count(*) FROM duckdb_sequences() WHERE sequence_name = ?", "users_id_seq").Row().Scan(&count))
+		return
+	}
+
+	assert.NoError(t, db.AutoMigrate(&User{}))
+	assert.NoError(t, db.Create(&User{Name: "first", Email: "first@example.com"}).Error)
+	assert.Equal(t, int64(1), countSequences())
+
+	assert.NoError(t, db.Migrator().DropTable(&User{}))
+	assert.Equal(t, int64(0), countSequences())
+
+	assert.NoError(t, db.AutoMigrate(&User{}))
+	user := User{Name: "again", Email: "again@example.com"}
+	assert.NoError(t, db.Create(&user).Error)
+	assert.Equal(t, uint(1), user.ID)
+}
+
+type Member struct {
+	UserID uint   `gorm:"column:user_id;primaryKey;autoIncrement"`
+	Name   string `gorm:"column:name"`
+}
+
+// TestPrimaryKeySequence verifies a primary key not named id gets its own sequence starting at 1.
+func TestPrimaryKeySequence(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Member{}))
+
+	var count int64
+	assert.NoError(t, db.Raw("SELECT count(*) FROM duckdb_sequences() WHERE sequence_name = ?", "members_user_id_seq").Row().Scan(&count))
+	assert.Equal(t, int64(1), count)
+
+	members := []Member{{Name: "ann"}, {Name: "bob"}}
+	assert.NoError(t, db.Create(&members).Error)
+	assert.Equal(t, uint(1), members[0].UserID)
+	assert.Equal(t, uint(2), members[1].UserID)
+
+	assert.NoError(t, db.Migrator().DropTable(&Member{}))
+	assert.NoError(t, db.Raw("SELECT count(*) FROM duckdb_sequences() WHERE sequence_name = ?", "members_user_id_seq").Row().Scan(&count))
+	assert.Equal(t, int64(0), count)
+}
+
+// TestTruncateTable verifies rows are removed and ids start again at 1.
+func TestTruncateTable(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Product{}, &Member{}))
+	assert.NoError(t, db.Create(&[]Product{{Name: "a"}, {Name: "b"}}).Error)
+	assert.NoError(t, db.Create(&[]Member{{Name: "ann"}}).Error)
+
+	m := db.Migrator().(duckdb.Migrator)
+	assert.NoError(t, m.TruncateTable(&Product{}, &Member{}))
+
+	var count int64
+	assert.NoError(t, db.Model(&Product{}).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+
+	product := Product{Name: "c"}
+	assert.NoError(t, db.Create(&product).Error)
+	assert.Equal(t, uint(1), product.ID)
+	member := Member{Name: "bob"}
+	assert.NoError(t, db.Create(&member).Error)
+	assert.Equal(t, uint(1), member.UserID)
+
+	assert.NoError(t, db.AutoMigrate(&IndexedItem{}))
+	assert.NoError(t, db.Create(&[]IndexedItem{{SKU: "a-1"}, {SKU: "b-2"}}).Error)
+	assert.NoError(t, m.TruncateTable(&IndexedItem{}))
+	assert.True(t, m.HasIndex(&IndexedItem{}, "idx_indexed_items_sku"))
+	item := IndexedItem{SKU: "c-3"}
+	assert.NoError(t, db.Create(&item).Error)
+	assert.Equal(t, uint(1), item.ID)
+
+	assert.ErrorIs(t, m.TruncateTable("missing_table"), duckdb.ErrTruncateTable)
+}
+
+// TestGetTablesInSchema verifies tables are listed per schema, including the schema of an attached database.
+func TestGetTablesInSchema(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Product{}))
+	assert.NoError(t, db.Exec("CREATE SCHEMA reporting").Error)
+	assert.NoError(t, db.Exec("CREATE TABLE reporting.daily_totals (day DATE, total BIGINT)").Error)
+	assert.NoError(t, db.Exec("ATTACH ':memory:' AS other").Error)
+	assert.NoError(t, db.Exec("CREATE TABLE other.main.archived (id INTEGER)").Error)
+
+	m := db.Migrator().(duckdb.Migrator)
+	tables, err := m.GetTablesInSchema("reporting")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"daily_totals"}, tables)
+
+	tables, err = m.GetTablesInSchema("other.main")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"archived"}, tables)
+
+	tables, err = m.GetTables()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"products"}, tables)
+
+	tables, err = m.GetTablesInSchema("missing")
+	assert.NoError(t, err)
+	assert.Empty(t, tables)
+}
+
+// TestGetViews verifies views are listed apart from tables and found with or without schema.
+func TestGetViews(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Product{}))
+	assert.NoError(t, db.Exec("CREATE VIEW product_names AS SELECT name FROM products").Error)
+	assert.NoError(t, db.Exec("CREATE SCHEMA reporting").Error)
+	assert.NoError(t, db.Exec("CREATE VIEW reporting.product_count AS SELECT count(*) AS total FROM products").Error)
+
+	m := db.Migrator().(duckdb.Migrator)
+	views, err := m.GetViews()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"product_names"}, views)
+
+	tables, err := m.GetTables()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"products"}, tables)
+
+	assert.True(t, m.HasView("product_names"))
+	assert.True(t, m.HasView("reporting.product_count"))
+	assert.False(t, m.HasView("product_count"))
+	assert.False(t, m.HasView("products"))
+	assert.False(t, m.HasTable("product_names"))
+}
+
+// TestMaterializedView verifies the stored result only changes when the view is refreshed.
+func TestMaterializedView(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Product{}))
+	assert.NoError(t, db.Create(&Product{Name: "pen", Price: 2}).Error)
+
+	m := db.Migrator().(duckdb.Migrator)
+	query := "SELECT count(*) AS total, sum(price) AS revenue FROM products"
+	assert.NoError(t, m.CreateMaterializedView("product_totals", query))
+	assert.Error(t, m.CreateMaterializedView("product_totals", query))
+
+	assert.NoError(t, db.Create(&Product{Name: "desk", Price: 150}).Error)
+	var total int64
+	assert.NoError(t, db.Raw("SELECT total FROM product_totals").Scan(&total).Error)
+	assert.Equal(t, int64(1), total)
+
+	assert.NoError(t, m.RefreshMaterializedView("product_totals", query))
+	assert.NoError(t, db.Raw("SELECT total FROM product_totals").Scan(&total).Error)
+	assert.Equal(t, int64(2), total)
+
+	assert.Error(t, m.RefreshMaterializedView("product_totals", "SELECT missing FROM products"))
+	assert.NoError(t, db.Raw("SELECT total FROM product_totals").Scan(&total).Error)
+	assert.Equal(t, int64(2), total)
+}
+
+// TestListSchemas verifies schemas of the current database are listed and those of attached databases are not.
+func TestListSchemas(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE SCHEMA staging").Error)
+	assert.NoError(t, db.Exec("ATTACH ':memory:' AS other").Error)
+	assert.NoError(t, db.Exec("CREATE SCHEMA other.elsewhere").Error)
+
+	m := db.Migrator().(duckdb.Migrator)
+	schemas, err := m.ListSchemas()
+	assert.NoError(t, err)
+	assert.Contains(t, schemas, "main")
+	assert.Contains(t, schemas, "staging")
+	assert.NotContains(t, schemas, "elsewhere")
+
+	assert.True(t, m.HasSchema("staging"))
+	assert.False(t, m.HasSchema("elsewhere"))
+	assert.False(t, m.HasSchema("missing"))
+}
+
+type StagedOrder struct {
+	ID    uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Label string `gorm:"column:label"`
+}
+
+func (StagedOrder) TableName() string {
+	return "staging.staged_orders"
+}
+
+// TestCreateDropSchema verifies schemas are created and dropped with their tables and sequences.
+func TestCreateDropSchema(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	m := db.Migrator().(duckdb.Migrator)
+	assert.NoError(t, m.CreateSchema("staging"))
+	assert.NoError(t, m.CreateSchema("staging"))
+	assert.True(t, m.HasSchema("staging"))
+
+	assert.NoError(t, db.AutoMigrate(&StagedOrder{}))
+	order := StagedOrder{Label: "first"}
+	assert.NoError(t, db.Create(&order).Error)
+	assert.NotZero(t, order.ID)
+
+	var count int64
+	assert.NoError(t, db.Raw("SELECT count(*) FROM staging.staged_orders").Row().Scan(&count))
+	assert.Equal(t, int64(1), count)
+
+	assert.Error(t, m.DropSchema("staging", false))
+	assert.NoError(t, m.DropSchema("staging", true))
+	assert.False(t, m.HasSchema("staging"))
+	assert.NoError(t, m.DropSchema("staging", false))
+	assert.False(t, m.HasTable(&StagedOrder{}))
+
+	var sequences int64
+	assert.NoError(t, db.Raw("SELECT count(*) FROM duckdb_sequences() WHERE sequence_name LIKE 'staged_orders%'").Row().Scan(&sequences))
+	assert.Equal(t, int64(0), sequences)
+
+	assert.ErrorIs(t, m.CreateSchema("bad;name"), duckdb.ErrInvalidIdentifier)
+	assert.ErrorIs(t, m.DropSchema("x; DROP TABLE y", true), duckdb.ErrInvalidIdentifier)
+}
+
+// TestAttachDatabase verifies attached databases are listed, writable unless read-only, and detached.
+func TestAttachDatabase(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	dir := t.TempDir()
+	var m duckdb.DatabaseManager = db.Migrator().(duckdb.Migrator)
+
+	assert.NoError(t, m.AttachDatabase(dir+"/sales.db", "sales", false))
+	assert.NoError(t, m.AttachDatabase(dir+"/sales.db", "sales", false))
+	assert.NoError(t, db.Exec("CREATE TABLE sales.orders (id INTEGER)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO sales.orders VALUES (1), (2)").Error)
+	assert.NoError(t, m.DetachDatabase("sales"))
+
+	assert.NoError(t, m.AttachDatabase(dir+"/sales.db", "archive", true))
+	var count int64
+	assert.NoError(t, db.Raw("SELECT count(*) FROM archive.orders").Row().Scan(&count))
+	assert.Equal(t, int64(2), count)
+	assert.Error(t, db.Exec("INSERT INTO archive.orders VALUES (3)").Error)
+
+	aliases, err := m.ListAttachedDatabases()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"archive"}, aliases)
+
+	assert.NoError(t, m.DetachDatabase("archive"))
+	aliases, err = m.ListAttachedDatabases()
+	assert.NoError(t, err)
+	assert.Empty(t, aliases)
+
+	assert.ErrorIs(t, m.AttachDatabase(dir+"/x.db", "bad alias", false), duckdb.ErrInvalidIdentifier)
+}
+
 func TestAutoIncrement(t *testing.T) {
 	db := initDB(t)
 	defer closeDB(t, db)
@@ -249,3 +511,541 @@ func TestCustomFieldsWithoutDeletedAt(t *testing.T) {
 	// This demonstrates that without deleted_at, there are no constraint issues
 	t.Logf("Successfully created user with same email after hard delete")
 }
+
+// TestCurrentSchemaQualifiedNames verifies schema and table parsing of quoted, plain and catalog-qualified names,
+// and that lookups of catalog-qualified tables stay in that catalog.
+func TestCurrentSchemaQualifiedNames(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	m := db.Migrator().(duckdb.Migrator)
+	cases := []struct {
+		name, schema, table string
+	}{
+		{`"my-schema"."odd.table"`, "my-schema", "odd.table"},
+		{`my_schema.my_table`, "my_schema", "my_table"},
+		{`test."my-schema"."odd.table"`, "my-schema", "odd.table"},
+		{`"my catalog"."say ""hi"""."t$1"`, `say "hi"`, "t$1"},
+	}
+	for _, c := range cases {
+		schema, table := m.CurrentSchema(db.Statement, c.name)
+		assert.Equal(t, c.schema, schema, c.name)
+		assert.Equal(t, c.table, table, c.name)
+	}
+
+	stmt := db.Table(`"my-schema"."odd table"`).Statement
+	schema, table := m.CurrentSchema(stmt, stmt.Table)
+	assert.Equal(t, "my-schema", schema)
+	assert.Equal(t, "odd table", table)
+
+	assert.NoError(t, db.Exec(`CREATE SCHEMA "my-schema"`).Error)
+	assert.NoError(t, db.Exec(`CREATE TABLE "my-schema"."odd.table" (id INTEGER)`).Error)
+	for _, name := range []string{`"my-schema"."odd.table"`, `test."my-schema"."odd.table"`} {
+		assert.True(t, m.HasTable(name), name)
+	}
+	assert.False(t, m.HasTable(`"my-schema"."missing"`))
+
+	// the catalog part selects the attached database, not the current one
+	assert.NoError(t, m.AttachDatabase(t.TempDir()+"/other.db", "other", false))
+	assert.NoError(t, db.Exec(`CREATE TABLE other.main.only_other (id INTEGER, name VARCHAR)`).Error)
+	assert.False(t, m.HasTable(`other."my-schema"."odd.table"`))
+	assert.False(t, m.HasTable(`test.main.only_other`))
+	assert.True(t, m.HasTable(`other.main.only_other`))
+	assert.True(t, m.HasColumn(`other.main.only_other`, "name"))
+	columnTypes, err := m.ColumnTypes(`other.main.only_other`)
+	assert.NoError(t, err)
+	assert.Len(t, columnTypes, 2)
+}
+
+// TestColumnTypes verifies every gorm.ColumnType method and the DuckDB storage extras of ColumnTypes.
+func TestColumnTypes(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE column_types (id INTEGER NOT NULL, name VARCHAR NOT NULL, price DECIMAL(10,2), note VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO column_types SELECT range, 'item ' || range, range / 100, NULL FROM range(10000)").Error)
+	assert.NoError(t, duckdb.Checkpoint(db))
+
+	columnTypes, err := db.Migrator().ColumnTypes("column_types")
+	assert.NoError(t, err)
+	assert.Len(t, columnTypes, 4)
+
+	expected := []struct {
+		name     string
+		dataType string
+		nullable bool
+		scanType reflect.Type
+	}{
+		{"id", "INTEGER", false, reflect.TypeOf(int32(0))},
+		{"name", "VARCHAR", false, reflect.TypeOf("")},
+		{"price", "DECIMAL", true, reflect.TypeOf(goduckdb.Decimal{})},
+		{"note", "VARCHAR", true, reflect.TypeOf("")},
+	}
+	for i, column := range columnTypes {
+		assert.Equal(t, expected[i].name, column.Name())
+		assert.Equal(t, expected[i].dataType, column.DatabaseTypeName(), column.Name())
+		assert.Equal(t, expected[i].scanType, column.ScanType(), column.Name())
+
+		nullable, ok := column.Nullable()
+		assert.True(t, ok)
+		assert.Equal(t, expected[i].nullable, nullable, column.Name())
+
+		_, ok = column.Length()
+		assert.False(t, ok, column.Name())
+
+		precision, scale, ok := column.DecimalSize()
+		if column.Name() == "price" {
+			assert.True(t, ok)
+			assert.Equal(t, int64(10), precision)
+			assert.Equal(t, int64(2), scale)
+
+			fullType, ok := column.ColumnType()
+			assert.True(t, ok)
+			assert.Equal(t, "DECIMAL(10,2)", fullType)
+		} else {
+			assert.False(t, ok, column.Name())
+		}
+
+		duckColumn, ok := column.(duckdb.DuckDBColumnType)
+		assert.True(t, ok)
+		assert.NotEmpty(t, duckColumn.CompressionType(), column.Name())
+		if column.Name() == "id" {
+			assert.Greater(t, duckColumn.StorageSize(), int64(0))
+			assert.True(t, duckColumn.HasStatistics())
+		}
+	}
+}
+
+type ColumnTypeItem struct {
+	ID    uint    `gorm:"column:id;primaryKey;autoIncrement"`
+	Code  string  `gorm:"column:code;unique"`
+	Label string  `gorm:"column:label;default:'none'"`
+	Price float64 `gorm:"column:price;precision:10;scale:2"`
+	Qty   int     `gorm:"column:qty;not null;default:0"`
+}
+
+// TestColumnTypesAutoMigrate verifies keys and defaults are reported and a repeated AutoMigrate alters nothing.
+func TestColumnTypesAutoMigrate(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&ColumnTypeItem{}))
+
+	columnTypes, err := db.Migrator().ColumnTypes(&ColumnTypeItem{})
+	assert.NoError(t, err)
+	columns := map[string]gorm.ColumnType{}
+	for _, column := range columnTypes {
+		columns[column.Name()] = column
+	}
+	if !assert.Len(t, columns, 5) {
+		return
+	}
+
+	primaryKey, ok := columns["id"].PrimaryKey()
+	assert.True(t, ok)
+	assert.True(t, primaryKey)
+	autoIncrement, ok := columns["id"].AutoIncrement()
+	assert.True(t, ok)
+	assert.True(t, autoIncrement)
+
+	unique, ok := columns["code"].Unique()
+	assert.True(t, ok)
+	assert.True(t, unique)
+	unique, _ = columns["label"].Unique()
+	assert.False(t, unique)
+
+	defaultValue, ok := columns["label"].DefaultValue()
+	assert.True(t, ok)
+	assert.Equal(t, "none", defaultValue)
+	defaultValue, ok = columns["qty"].DefaultValue()
+	assert.True(t, ok)
+	assert.Equal(t, "0", defaultValue)
+	_, ok = columns["price"].DefaultValue()
+	assert.False(t, ok)
+
+	nullable, _ := columns["qty"].Nullable()
+	assert.False(t, nullable)
+	precision, scale, ok := columns["price"].DecimalSize()
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), precision)
+	assert.Equal(t, int64(2), scale)
+	assert.Contains(t, db.Migrator().GetTypeAliases(strings.ToLower(columns["label"].DatabaseTypeName())), "text")
+
+	var statements []string
+	assert.NoError(t, db.Callback().Raw().After("gorm:raw").Register("test:capture_sql", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+	assert.NoError(t, db.AutoMigrate(&ColumnTypeItem{}))
+	for _, statement := range statements {
+		assert.NotContains(t, statement, "ALTER TABLE")
+	}
+}
+
+type NumericItem struct {
+	ID     uint    `gorm:"column:id;primaryKey;autoIncrement"`
+	Huge   int64   `gorm:"column:huge;type:int128"`
+	UHuge  uint64  `gorm:"column:u_huge;type:uint128"`
+	UInt   uint32  `gorm:"column:u_int;type:uint32"`
+	UBig   uint64  `gorm:"column:u_big;type:uint64"`
+	USmall uint16  `gorm:"column:u_small;type:uint16"`
+	UTiny  uint8   `gorm:"column:u_tiny;type:uint8"`
+	Ratio  float32 `gorm:"column:ratio;type:float4"`
+}
+
+// TestNumericTypeAliases verifies columns declared with DuckDB's numeric type aliases are not altered again.
+func TestNumericTypeAliases(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&NumericItem{}))
+
+	var statements []string
+	assert.NoError(t, db.Callback().Raw().After("gorm:raw").Register("test:capture_sql", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+	assert.NoError(t, db.AutoMigrate(&NumericItem{}))
+	for _, statement := range statements {
+		assert.NotContains(t, statement, "ALTER TABLE")
+	}
+
+	m := db.Migrator()
+	assert.Contains(t, m.GetTypeAliases("uinteger"), "uint32")
+	assert.Contains(t, m.GetTypeAliases("hugeint"), "int128")
+}
+
+type TimestampItem struct {
+	ID       uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Seconds  time.Time `gorm:"column:seconds;type:timestamp_s"`
+	Millis   time.Time `gorm:"column:millis;type:timestamp_ms"`
+	Nanos    time.Time `gorm:"column:nanos;type:timestamp_ns"`
+	OpensAt  time.Time `gorm:"column:opens_at;type:timetz"`
+	Recorded time.Time `gorm:"column:recorded"`
+}
+
+// TestTimestampTypeAliases verifies timestamp variant and TIMETZ columns are not altered by AutoMigrate,
+// including a TIMESTAMP_MS column created outside gorm for an untagged time.Time field.
+func TestTimestampTypeAliases(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&TimestampItem{}))
+	assert.NoError(t, db.Exec("ALTER TABLE timestamp_items ALTER COLUMN recorded TYPE TIMESTAMP_MS").Error)
+
+	var statements []string
+	assert.NoError(t, db.Callback().Raw().After("gorm:raw").Register("test:capture_sql", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+	assert.NoError(t, db.AutoMigrate(&TimestampItem{}))
+	for _, statement := range statements {
+		assert.NotContains(t, statement, "ALTER TABLE")
+	}
+
+	var columnType string
+	assert.NoError(t, db.Raw("SELECT data_type FROM duckdb_columns() WHERE table_name = ? AND column_name = ?", "timestamp_items", "recorded").Row().Scan(&columnType))
+	assert.Equal(t, "TIMESTAMP_MS", columnType)
+}
+
+type AlterItem struct {
+	ID    uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Qty   int32  `gorm:"column:qty"`
+	Label string `gorm:"column:label;default:'none'"`
+}
+
+type AlteredItem struct {
+	ID    uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Qty   int64  `gorm:"column:qty;default:5"`
+	Label string `gorm:"column:label"`
+}
+
+func (AlteredItem) TableName() string {
+	return "alter_items"
+}
+
+// TestAlterColumn verifies AutoMigrate changes a column type, adds and drops defaults, and keeps the id sequence.
+func TestAlterColumn(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&AlterItem{}))
+	assert.NoError(t, db.Create(&AlterItem{Qty: 1}).Error)
+	assert.NoError(t, db.AutoMigrate(&AlteredItem{}))
+
+	var qtyType string
+	assert.NoError(t, db.Raw("SELECT data_type FROM duckdb_columns() WHERE table_name = ? AND column_name = ?", "alter_items", "qty").Row().Scan(&qtyType))
+	assert.Equal(t, "BIGINT", qtyType)
+
+	assert.NoError(t, db.Exec("INSERT INTO alter_items DEFAULT VALUES").Error)
+	var rows []struct {
+		ID    uint
+		Qty   int64
+		Label *string
+	}
+	assert.NoError(t, db.Table("alter_items").Order("id").Find(&rows).Error)
+	if !assert.Len(t, rows, 2) {
+		return
+	}
+	if assert.NotNil(t, rows[0].Label) {
+		assert.Equal(t, "none", *rows[0].Label)
+	}
+	assert.Equal(t, uint(2), rows[1].ID)
+	assert.Equal(t, int64(5), rows[1].Qty)
+	assert.Nil(t, rows[1].Label)
+
+	assert.NoError(t, db.Migrator().AlterColumn(&AlteredItem{}, "Qty"))
+	assert.Error(t, db.Migrator().AlterColumn(&AlteredItem{}, "Missing"))
+}
+
+type NullableItem struct {
+	ID   uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Name string `gorm:"column:name"`
+}
+
+type RequiredItem struct {
+	ID   uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Name string `gorm:"column:name;not null"`
+}
+
+func (RequiredItem) TableName() string {
+	return "nullable_items"
+}
+
+// TestNotNull verifies AutoMigrate sets and drops NOT NULL when the tag changes, and the helpers work directly.
+func TestNotNull(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&NullableItem{}))
+	assert.NoError(t, db.Exec("INSERT INTO nullable_items (name) VALUES (NULL)").Error)
+	assert.NoError(t, db.Exec("DELETE FROM nullable_items").Error)
+
+	assert.NoError(t, db.AutoMigrate(&RequiredItem{}))
+	assert.Error(t, db.Exec("INSERT INTO nullable_items (name) VALUES (NULL)").Error)
+
+	assert.NoError(t, db.AutoMigrate(&NullableItem{}))
+	assert.NoError(t, db.Exec("INSERT INTO nullable_items (name) VALUES (NULL)").Error)
+
+	m := db.Migrator().(duckdb.Migrator)
+	assert.Error(t, m.SetNotNull(&NullableItem{}, "Name"))
+	assert.NoError(t, db.Exec("DELETE FROM nullable_items").Error)
+	assert.NoError(t, m.SetNotNull(&NullableItem{}, "Name"))
+	assert.Error(t, db.Exec("INSERT INTO nullable_items (name) VALUES (NULL)").Error)
+	assert.NoError(t, m.DropNotNull(&NullableItem{}, "name"))
+	assert.NoError(t, db.Exec("INSERT INTO nullable_items (name) VALUES (NULL)").Error)
+}
+
+type Visit struct {
+	ID   uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Page string `gorm:"column:page"`
+}
+
+type TimedVisit struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Page      string    `gorm:"column:page"`
+	VisitedAt time.Time `gorm:"column:visited_at;default:current_timestamp"`
+	Day       string    `gorm:"column:day;default:current_date"`
+	Source    string    `gorm:"column:source;not null;default:'web'"`
+}
+
+func (TimedVisit) TableName() string {
+	return "visits"
+}
+
+// TestAddColumnDefault verifies added columns get expression and constant defaults, and NOT NULL.
+func TestAddColumnDefault(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Visit{}))
+	assert.NoError(t, db.Create(&Visit{Page: "/old"}).Error)
+	assert.NoError(t, db.AutoMigrate(&TimedVisit{}))
+
+	before := time.Now().Add(-time.Minute)
+	assert.NoError(t, db.Exec("INSERT INTO visits (page) VALUES ('/new')").Error)
+
+	var visit TimedVisit
+	assert.NoError(t, db.Where("page = ?", "/new").First(&visit).Error)
+	assert.True(t, visit.VisitedAt.After(before), visit.VisitedAt)
+	assert.NotEqual(t, "current_date", visit.Day)
+	assert.Len(t, visit.Day, len("2006-01-02"))
+	assert.Equal(t, "web", visit.Source)
+
+	var old TimedVisit
+	assert.NoError(t, db.Where("page = ?", "/old").First(&old).Error)
+	assert.Equal(t, "web", old.Source)
+	assert.Error(t, db.Exec("INSERT INTO visits (page, source) VALUES ('/null', NULL)").Error)
+
+	var columnDefault string
+	assert.NoError(t, db.Raw("SELECT column_default FROM duckdb_columns() WHERE table_name = ? AND column_name = ?", "visits", "source").Row().Scan(&columnDefault))
+	assert.Equal(t, "'web'", columnDefault)
+}
+
+type IndexedItem struct {
+	ID  uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	SKU string `gorm:"column:sku;index:idx_indexed_items_sku"`
+}
+
+// TestRenameIndex verifies the index is recreated under the new name and the old name is gone.
+func TestRenameIndex(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&IndexedItem{}))
+	assert.NoError(t, db.Create(&[]IndexedItem{{SKU: "a-1"}, {SKU: "b-2"}}).Error)
+
+	m := db.Migrator()
+	assert.True(t, m.HasIndex(&IndexedItem{}, "idx_indexed_items_sku"))
+	assert.NoError(t, m.RenameIndex(&IndexedItem{}, "idx_indexed_items_sku", "idx_items_sku_renamed"))
+	assert.False(t, m.HasIndex(&IndexedItem{}, "idx_indexed_items_sku"))
+	assert.True(t, m.HasIndex(&IndexedItem{}, "idx_items_sku_renamed"))
+
+	var item IndexedItem
+	assert.NoError(t, db.Where("sku = ?", "b-2").First(&item).Error)
+	assert.Equal(t, uint(2), item.ID)
+
+	assert.ErrorIs(t, m.RenameIndex(&IndexedItem{}, "idx_missing", "idx_other"), duckdb.ErrIndexNotFound)
+	assert.ErrorIs(t, m.RenameIndex(&IndexedItem{}, "idx_items_sku_renamed", "bad name"), duckdb.ErrInvalidIdentifier)
+}
+
+// TestHasIndex verifies indexes are found by table and schema, including unique indexes.
+func TestHasIndex(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE SCHEMA archive").Error)
+	assert.NoError(t, db.Exec("CREATE TABLE indexed_rows (id INTEGER, code VARCHAR)").Error)
+	assert.NoError(t, db.Exec("CREATE TABLE archive.indexed_rows (id INTEGER, code VARCHAR)").Error)
+	assert.NoError(t, db.Exec("CREATE INDEX idx_indexed_rows_id ON indexed_rows (id)").Error)
+	assert.NoError(t, db.Exec("CREATE UNIQUE INDEX idx_indexed_rows_code ON archive.indexed_rows (code)").Error)
+
+	m := db.Migrator()
+	assert.True(t, m.HasIndex("indexed_rows", "idx_indexed_rows_id"))
+	assert.False(t, m.HasIndex("indexed_rows", "idx_indexed_rows_code"))
+	assert.True(t, m.HasIndex("archive.indexed_rows", "idx_indexed_rows_code"))
+	assert.False(t, m.HasIndex("archive.indexed_rows", "idx_indexed_rows_id"))
+}
+
+type EnumAccount struct {
+	ID     uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Status string `gorm:"column:status;type:enum('active','inactive')"`
+}
+
+// TestEnum verifies enum fields get an ENUM type created before the table and the enum methods manage types.
+func TestEnum(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	m := db.Migrator().(duckdb.Migrator)
+	assert.NoError(t, db.AutoMigrate(&EnumAccount{}))
+	assert.True(t, m.HasEnum("enum_accounts_status_enum"))
+
+	assert.NoError(t, db.Create(&EnumAccount{Status: "active"}).Error)
+	assert.Error(t, db.Create(&EnumAccount{Status: "deleted"}).Error)
+
+	assert.NoError(t, m.CreateEnum("mood", []string{"sad", "it's fine"}))
+	assert.True(t, m.HasEnum("mood"))
+	var values []string
+	assert.NoError(t, db.Raw("SELECT unnest(enum_range(NULL::mood))").Scan(&values).Error)
+	assert.Equal(t, []string{"sad", "it's fine"}, values)
+	assert.NoError(t, m.DropEnum("mood"))
+	assert.False(t, m.HasEnum("mood"))
+
+	assert.NoError(t, m.DropTable(&EnumAccount{}))
+	assert.NoError(t, m.DropEnum("enum_accounts_status_enum"))
+	assert.False(t, m.HasEnum("enum_accounts_status_enum"))
+}
+
+// TestGetVersion verifies the version string is returned as is and parsed into its numbers.
+func TestGetVersion(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	m := db.Migrator().(duckdb.Migrator)
+	version, err := m.GetVersion()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(version, "v"), version)
+
+	major, minor, patch, err := m.GetDuckDBVersion()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(version, fmt.Sprintf("v%d.%d.%d", major, minor, patch)), version)
+	assert.GreaterOrEqual(t, major, 1)
+}
+
+// TestGetSettings verifies settings changed with SET are read back, alone and among all settings.
+func TestGetSettings(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("SET threads = 2").Error)
+
+	m := db.Migrator().(duckdb.Migrator)
+	threads, err := m.GetSetting("threads")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", threads)
+
+	settings, err := m.GetSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", settings["threads"])
+	assert.Contains(t, settings, "max_memory")
+
+	_, err = m.GetSetting("thread")
+	assert.ErrorIs(t, err, duckdb.ErrUnknownSetting)
+}
+
+// TestSetSetting verifies settings are changed and reset at runtime and unknown names are rejected.
+func TestSetSetting(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	m := db.Migrator().(duckdb.Migrator)
+	defaultThreads, err := m.GetSetting("threads")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.SetSetting("threads", "3"))
+	threads, err := m.GetSetting("threads")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", threads)
+
+	assert.NoError(t, m.ResetSetting("threads"))
+	threads, err = m.GetSetting("threads")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultThreads, threads)
+
+	assert.ErrorIs(t, m.SetSetting("thread", "3"), duckdb.ErrUnknownSetting)
+	assert.ErrorIs(t, m.SetSetting("threads = 1; DROP TABLE x; --", "3"), duckdb.ErrUnknownSetting)
+	assert.ErrorIs(t, m.ResetSetting("thread"), duckdb.ErrUnknownSetting)
+	assert.Error(t, m.SetSetting("threads", "many"))
+}
+
+type Ledger struct {
+	ID     uint    `gorm:"column:id;primaryKey;autoIncrement"`
+	Amount float64 `gorm:"column:amount;comment:amount in cents"`
+}
+
+type RenotedLedger struct {
+	ID     uint    `gorm:"column:id;primaryKey;autoIncrement"`
+	Amount float64 `gorm:"column:amount;comment:owner's amount in euros"`
+	Note   string  `gorm:"column:note"`
+}
+
+func (RenotedLedger) TableName() string {
+	return "ledgers"
+}
+
+// TestMigrateColumnComment verifies comments are compared through duckdb_columns and only changed ones are updated.
+func TestMigrateColumnComment(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Ledger{}))
+	assert.NoError(t, db.AutoMigrate(&Ledger{}))
+
+	comment := func() string {
+		var value string
+		assert.NoError(t, db.Raw("SELECT comment FROM duckdb_columns() WHERE table_name = ? AND column_name = ?", "ledgers", "amount").Row().Scan(&value))
+		return value
+	}
+	assert.Equal(t, "amount in cents", comment())
+
+	assert.NoError(t, db.AutoMigrate(&RenotedLedger{}))
+	assert.Equal(t, "owner's amount in euros", comment())
+}
@@ -0,0 +1,34 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import "gorm.io/gorm"
+
+const enableObjectCacheSQL = "PRAGMA enable_object_cache"
+
+// EnableObjectCache sets enable_object_cache. Since DuckDB 1.4 the setting is a no-op kept for
+// compatibility: Parquet metadata is cached regardless and current_setting keeps reporting false,
+// so this only matters for older DuckDB versions.
+func EnableObjectCache(db *gorm.DB) error {
+	return db.Exec(enableObjectCacheSQL).Error
+}
+
+// DisableObjectCache unsets enable_object_cache, a no-op since DuckDB 1.4 like EnableObjectCache.
+func DisableObjectCache(db *gorm.DB) error {
+	return db.Exec("PRAGMA disable_object_cache").Error
+}
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestObjectCache verifies the object cache pragmas are accepted at runtime and at open time.
+func TestObjectCache(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, duckdb.EnableObjectCache(db))
+	assert.NoError(t, db.Exec("CREATE TABLE cached AS SELECT range AS id FROM range(10)").Error)
+	var count int64
+	assert.NoError(t, db.Raw("SELECT count(*) FROM cached").Scan(&count).Error)
+	assert.Equal(t, int64(10), count)
+	assert.NoError(t, duckdb.DisableObjectCache(db))
+
+	memDB, err := gorm.Open(duckdb.New(duckdb.Config{DSN: "", EnableObjectCache: true}), &gorm.Config{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	sqlDB, err := memDB.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, sqlDB.Close())
+}
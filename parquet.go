@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ParquetIO moves rows of a single table between DuckDB and Parquet files, see ExportToParquet
+// and ImportFromParquet for the whole database.
+type ParquetIO struct {
+	db *gorm.DB
+}
+
+func NewParquetIO(db *gorm.DB) *ParquetIO {
+	return &ParquetIO{db: db}
+}
+
+// ImportTable appends the rows of the Parquet file at filePath to the table of dst, a model or a table name.
+// Columns are matched by name, so the file may hold them in any order and omit those with defaults.
+func (p *ParquetIO) ImportTable(dst interface{}, filePath string) error {
+	return runWithTable(p.db, dst, func(table clause.Table) error {
+		return p.db.Exec("INSERT INTO ? BY NAME SELECT * FROM read_parquet(?)", table, filePath).Error
+	})
+}
+
+// ExportTable writes the rows of the table of dst, a model or a table name, to the Parquet file at filePath.
+// where takes the arguments of gorm's Where to export only the matching rows.
+func (p *ParquetIO) ExportTable(dst interface{}, filePath string, where ...interface{}) error {
+	return copyTableTo(p.db, dst, filePath, FormatParquet, where)
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestParquetIO verifies rows are imported from a Parquet file and partial exports only hold the matching rows.
+func TestParquetIO(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&PoolItem{}))
+
+	fixture := filepath.Join(t.TempDir(), "items.parquet")
+	assert.NoError(t, db.Exec("COPY (SELECT * FROM (VALUES (1, 'bolt'), (5, 'nut'), (9, 'screw')) AS t(qty, name)) TO '"+fixture+"' (FORMAT PARQUET)").Error)
+
+	parquet := duckdb.NewParquetIO(db)
+	assert.NoError(t, parquet.ImportTable(&PoolItem{}, fixture))
+
+	var items []PoolItem
+	assert.NoError(t, db.Order("qty").Find(&items).Error)
+	assert.Equal(t, []PoolItem{{Name: "bolt", Qty: 1}, {Name: "nut", Qty: 5}, {Name: "screw", Qty: 9}}, items)
+
+	exported := filepath.Join(t.TempDir(), "large.parquet")
+	assert.NoError(t, parquet.ExportTable(&PoolItem{}, exported, "qty > ?", 3))
+
+	var names []string
+	assert.NoError(t, db.Raw("SELECT name FROM read_parquet(?) ORDER BY name", exported).Scan(&names).Error)
+	assert.Equal(t, []string{"nut", "screw"}, names)
+
+	assert.NoError(t, parquet.ImportTable("pool_items", exported))
+	var count int64
+	assert.NoError(t, db.Model(&PoolItem{}).Count(&count).Error)
+	assert.Equal(t, int64(5), count)
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidPivot = errors.New("invalid pivot")
+
+// PivotQuery turns the distinct values of PivotColumn into columns holding Aggregate(ValueColumn)
+// with DuckDB's PIVOT, one row per distinct GroupBy combination:
+//
+//	duckdb.PivotQuery{Source: db.Table("sales"), PivotColumn: "quarter", ValueColumn: "amount", GroupBy: []string{"region"}}
+//
+// Source may be any query, its conditions and selects apply before pivoting. DuckDB reads the
+// distinct values of PivotColumn before running the pivot, which it can't do with parameters,
+// so the vars of Source are inlined as literals.
+type PivotQuery struct {
+	Source      *gorm.DB
+	PivotColumn string
+	ValueColumn string
+	// Aggregate is the aggregate function applied to ValueColumn, sum by default.
+	Aggregate string
+	GroupBy   []string
+}
+
+// Scan runs the pivot and scans the result into dest, usually a slice of maps or
+// structs since the columns depend on the data.
+func (q PivotQuery) Scan(dest interface{}) error {
+	if q.Source == nil || q.PivotColumn == "" || q.ValueColumn == "" {
+		return ErrInvalidPivot
+	}
+
+	aggregate := q.Aggregate
+	if aggregate == "" {
+		aggregate = "sum"
+	}
+	if err := validIdentifier(aggregate); err != nil {
+		return err
+	}
+
+	source, err := inlineQuery(q.Source)
+	if err != nil {
+		return err
+	}
+
+	sql := "PIVOT (?) ON ? USING " + aggregate + "(?)"
+	vars := []interface{}{source, clause.Column{Name: q.PivotColumn}, clause.Column{Name: q.ValueColumn}}
+	if len(q.GroupBy) > 0 {
+		sql += " GROUP BY " + columnPlaceholders(q.GroupBy, &vars)
+	}
+	return q.Source.Session(&gorm.Session{NewDB: true}).Raw(sql, vars...).Scan(dest).Error
+}
+
+// UnpivotQuery turns Columns into rows with DuckDB's UNPIVOT, the column name goes into
+// NameColumn and its value into ValueColumn, the other columns of Source are kept.
+type UnpivotQuery struct {
+	Source  *gorm.DB
+	Columns []string
+	// NameColumn and ValueColumn default to name and value.
+	NameColumn  string
+	ValueColumn string
+}
+
+// Scan runs the unpivot and scans the result into dest.
+func (q UnpivotQuery) Scan(dest interface{}) error {
+	if q.Source == nil || len(q.Columns) == 0 {
+		return ErrInvalidPivot
+	}
+
+	nameColumn, valueColumn := q.NameColumn, q.ValueColumn
+	if nameColumn == "" {
+		nameColumn = "name"
+	}
+	if valueColumn == "" {
+		valueColumn = "value"
+	}
+
+	source, err := inlineQuery(q.Source)
+	if err != nil {
+		return err
+	}
+
+	vars := []interface{}{source}
+	sql := "UNPIVOT (?) ON " + columnPlaceholders(q.Columns, &vars) + " INTO NAME ? VALUE ?"
+	vars = append(vars, clause.Column{Name: nameColumn}, clause.Column{Name: valueColumn})
+	return q.Source.Session(&gorm.Session{NewDB: true}).Raw(sql, vars...).Scan(dest).Error
+}
+
+// inlineQuery renders query as SQL with its vars written as literals.
+func inlineQuery(query *gorm.DB) (clause.Expr, error) {
+	rendered := query.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]interface{}{})
+	if rendered.Error != nil {
+		return clause.Expr{}, rendered.Error
+	}
+	return clause.Expr{SQL: query.Dialector.Explain(rendered.Statement.SQL.String(), rendered.Statement.Vars...)}, nil
+}
+
+// columnPlaceholders returns one placeholder per column and appends the columns to vars.
+func columnPlaceholders(columns []string, vars *[]interface{}) string {
+	placeholders := make([]string, len(columns))
+	for i, column := range columns {
+		placeholders[i] = "?"
+		*vars = append(*vars, clause.Column{Name: column})
+	}
+	return strings.Join(placeholders, ", ")
+}
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type QuarterSales struct {
+	Region string  `gorm:"column:region"`
+	Q1     float64 `gorm:"column:Q1"`
+	Q2     float64 `gorm:"column:Q2"`
+}
+
+type RegionSale struct {
+	Region  string  `gorm:"column:region"`
+	Quarter string  `gorm:"column:quarter"`
+	Amount  float64 `gorm:"column:amount"`
+}
+
+// TestPivotUnpivot verifies quarters pivot into aggregated columns and unpivot back into rows.
+func TestPivotUnpivot(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE sales (region VARCHAR, quarter VARCHAR, amount DOUBLE)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO sales VALUES ('east', 'Q1', 10), ('east', 'Q1', 5), ('east', 'Q2', 7), ('west', 'Q2', 3), ('north', 'Q3', 1)").Error)
+
+	var pivoted []QuarterSales
+	err := duckdb.PivotQuery{
+		Source:      db.Table("sales").Where("quarter <> ?", "Q3"),
+		PivotColumn: "quarter",
+		ValueColumn: "amount",
+		GroupBy:     []string{"region"},
+	}.Scan(&pivoted)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []QuarterSales{{Region: "east", Q1: 15, Q2: 7}, {Region: "west", Q2: 3}}, pivoted)
+
+	var counts []map[string]interface{}
+	err = duckdb.PivotQuery{Source: db.Table("sales").Select("region", "amount"), PivotColumn: "region", ValueColumn: "amount", Aggregate: "count"}.Scan(&counts)
+	assert.NoError(t, err)
+	assert.Len(t, counts, 1)
+	assert.EqualValues(t, 3, counts[0]["east"])
+
+	var rows []RegionSale
+	err = duckdb.UnpivotQuery{
+		Source:      db.Table("(?) AS pivoted", db.Raw("SELECT 'east' AS region, 15.0 AS \"Q1\", 7.0 AS \"Q2\"")),
+		Columns:     []string{"Q1", "Q2"},
+		NameColumn:  "quarter",
+		ValueColumn: "amount",
+	}.Scan(&rows)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []RegionSale{{Region: "east", Quarter: "Q1", Amount: 15}, {Region: "east", Quarter: "Q2", Amount: 7}}, rows)
+
+	assert.ErrorIs(t, duckdb.PivotQuery{Source: db.Table("sales")}.Scan(&counts), duckdb.ErrInvalidPivot)
+	assert.ErrorIs(t, duckdb.PivotQuery{Source: db.Table("sales"), PivotColumn: "region", ValueColumn: "amount", Aggregate: "sum(1); --"}.Scan(&counts), duckdb.ErrInvalidIdentifier)
+}
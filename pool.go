@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	ErrInvalidIdentifier       = errors.New("invalid identifier")
+	ErrDatabaseNotAttached     = errors.New("database is not attached")
+	ErrDatabaseAlreadyAttached = errors.New("database is already attached")
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validIdentifier guards names that are written into DDL verbatim because they can't be bound as parameters.
+func validIdentifier(name string) error {
+	if !identifierRegexp.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidIdentifier, name)
+	}
+	return nil
+}
+
+// quoteIdentifier double quotes name, for identifiers written into SQL that can't be validated
+// up front because the caller has no error to return.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteQualifiedName quotes a possibly schema-qualified name part by part with quoteIdentifier,
+// a name that doesn't split into parts is quoted as a whole.
+func quoteQualifiedName(name string) string {
+	parts, ok := splitQualifiedName(name)
+	if !ok {
+		parts = []string{name}
+	}
+	for i, part := range parts {
+		parts[i] = quoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// DatabasePool manages databases attached to one DuckDB instance under their alias.
+// Attached databases are visible to every connection of the instance,
+// so all *gorm.DB handed out by the pool share the connection pool of the main database.
+type DatabasePool struct {
+	db  *gorm.DB
+	mu  sync.RWMutex
+	dbs map[string]*gorm.DB
+}
+
+func NewDatabasePool(db *gorm.DB) *DatabasePool {
+	return &DatabasePool{db: db, dbs: map[string]*gorm.DB{}}
+}
+
+// manager attaches and detaches through the Migrator, the pool only keeps track of the aliases.
+func (p *DatabasePool) manager() DatabaseManager {
+	return p.db.Migrator().(Migrator)
+}
+
+// Add attaches the database file at path as alias.
+func (p *DatabasePool) Add(alias, path string, readOnly bool) error {
+	if err := validIdentifier(alias); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.dbs[alias]; ok {
+		return fmt.Errorf("%w: %s", ErrDatabaseAlreadyAttached, alias)
+	}
+
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+
+	if err := p.manager().AttachDatabase(path, alias, readOnly); err != nil {
+		return err
+	}
+
+	// a separate gorm.DB keeps its own schema cache, so models resolve to tables in the main
+	// schema of the attached database, alias.main.table, rather than to a schema named alias
+	db, err := gorm.Open(New(Config{Conn: sqlDB}), &gorm.Config{
+		Logger:         p.db.Logger,
+		NamingStrategy: schema.NamingStrategy{TablePrefix: alias + ".main."},
+	})
+	if err != nil {
+		_ = p.manager().DetachDatabase(alias)
+		return err
+	}
+
+	p.dbs[alias] = db
+	return nil
+}
+
+// Remove detaches the database attached as alias.
+func (p *DatabasePool) Remove(alias string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.dbs[alias]; !ok {
+		return fmt.Errorf("%w: %s", ErrDatabaseNotAttached, alias)
+	}
+
+	if err := p.manager().DetachDatabase(alias); err != nil {
+		return err
+	}
+
+	delete(p.dbs, alias)
+	return nil
+}
+
+// Get returns a *gorm.DB whose models map to tables of the database attached as alias.
+func (p *DatabasePool) Get(alias string) (*gorm.DB, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	db, ok := p.dbs[alias]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrDatabaseNotAttached, alias)
+	}
+	return db, nil
+}
+
+// Close detaches all databases of the pool, the main database stays open.
+func (p *DatabasePool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for alias := range p.dbs {
+		if err := p.manager().DetachDatabase(alias); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(p.dbs, alias)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type PoolItem struct {
+	Name string `gorm:"column:name"`
+	Qty  int    `gorm:"column:qty"`
+}
+
+// TestDatabasePool verifies attached databases can be queried, removed and closed independently.
+func TestDatabasePool(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	dir := t.TempDir()
+	pool := duckdb.NewDatabasePool(db)
+	assert.NoError(t, pool.Add("warehouse", filepath.Join(dir, "warehouse.db"), false))
+	assert.NoError(t, pool.Add("archive", filepath.Join(dir, "archive.db"), false))
+	assert.ErrorIs(t, pool.Add("archive", filepath.Join(dir, "archive.db"), false), duckdb.ErrDatabaseAlreadyAttached)
+	assert.ErrorIs(t, pool.Add("bad alias", filepath.Join(dir, "bad.db"), false), duckdb.ErrInvalidIdentifier)
+
+	warehouse, err := pool.Get("warehouse")
+	assert.NoError(t, err)
+	archive, err := pool.Get("archive")
+	assert.NoError(t, err)
+
+	assert.NoError(t, warehouse.Migrator().CreateTable(&PoolItem{}))
+	assert.NoError(t, archive.Migrator().CreateTable(&PoolItem{}))
+	assert.NoError(t, warehouse.Create(&PoolItem{Name: "bolt", Qty: 10}).Error)
+	assert.NoError(t, archive.Create(&[]PoolItem{{Name: "nut", Qty: 1}, {Name: "screw", Qty: 2}}).Error)
+
+	var count int64
+	assert.NoError(t, warehouse.Model(&PoolItem{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+	assert.NoError(t, archive.Model(&PoolItem{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	assert.NoError(t, pool.Remove("warehouse"))
+	_, err = pool.Get("warehouse")
+	assert.ErrorIs(t, err, duckdb.ErrDatabaseNotAttached)
+	assert.NoError(t, db.Raw("SELECT count(*) FROM duckdb_databases() WHERE database_name = ?", "warehouse").Scan(&count).Error)
+	assert.Equal(t, int64(0), count)
+
+	var items []PoolItem
+	assert.NoError(t, archive.Order("name").Find(&items).Error)
+	if !assert.Len(t, items, 2) {
+		return
+	}
+	assert.Equal(t, "nut", items[0].Name)
+
+	assert.NoError(t, pool.Close())
+	assert.NoError(t, db.Raw("SELECT count(*) FROM duckdb_databases() WHERE database_name = ?", "archive").Scan(&count).Error)
+	assert.Equal(t, int64(0), count)
+}
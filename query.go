@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UnionByName combines queries with UNION ALL BY NAME, which aligns columns by name
+// instead of by position and fills columns missing from a query with NULL.
+// The result is a new query selecting from the union, aliased as union_by_name.
+// It returns nil when no query is given.
+func UnionByName(queries ...*gorm.DB) *gorm.DB {
+	if len(queries) == 0 {
+		return nil
+	}
+
+	parts := make([]string, len(queries))
+	vars := make([]interface{}, len(queries))
+	for i, query := range queries {
+		parts[i] = "(?)"
+		vars[i] = query
+	}
+
+	return queries[0].Session(&gorm.Session{NewDB: true}).
+		Table("("+strings.Join(parts, " UNION ALL BY NAME ")+") AS union_by_name", vars...)
+}
+
+// InListUnnestThreshold is the list size above which WhereInList binds the list
+// as a single array parameter and matches with IN (SELECT unnest(?)),
+// which DuckDB plans as a hash join instead of a long chain of comparisons.
+var InListUnnestThreshold = 100
+
+type int64List []int64
+
+func (l int64List) Value() (driver.Value, error) {
+	return []int64(l), nil
+}
+
+type uint64List []uint64
+
+func (l uint64List) Value() (driver.Value, error) {
+	return []uint64(l), nil
+}
+
+type stringList []string
+
+func (l stringList) Value() (driver.Value, error) {
+	return []string(l), nil
+}
+
+// WhereInList adds col IN vals to db. Lists of integers or strings longer than InListUnnestThreshold
+// use the unnest form, other lists the plain IN (...) form.
+func WhereInList(db *gorm.DB, col string, vals interface{}) *gorm.DB {
+	column := clause.Column{Name: col}
+
+	rv := reflect.Indirect(reflect.ValueOf(vals))
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() > InListUnnestThreshold {
+		switch rv.Type().Elem().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			list := make(int64List, rv.Len())
+			for i := range list {
+				list[i] = rv.Index(i).Int()
+			}
+			return db.Where("? IN (SELECT unnest(?::BIGINT[]))", column, list)
+		case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			// UBIGINT keeps values above math.MaxInt64, which would wrap around as BIGINT
+			list := make(uint64List, rv.Len())
+			for i := range list {
+				list[i] = rv.Index(i).Uint()
+			}
+			return db.Where("? IN (SELECT unnest(?::UBIGINT[]))", column, list)
+		case reflect.String:
+			list := make(stringList, rv.Len())
+			for i := range list {
+				list[i] = rv.Index(i).String()
+			}
+			return db.Where("? IN (SELECT unnest(?::VARCHAR[]))", column, list)
+		}
+	}
+
+	return db.Where("? IN ?", column, vals)
+}
+
+// NotInSubquery returns a condition for db.Where equivalent to col NOT IN (subquery),
+//
+//	db.Where(duckdb.NotInSubquery("customers.id", db.Table("orders").Select("customer_id")))
+//
+// It keeps the NULL semantics of NOT IN rather than those of a plain anti join: no row matches
+// when subquery returns a NULL, and a NULL col matches only when subquery is empty.
+// When subquery selects a single NOT NULL table column, plain NOT IN is emitted, otherwise it is
+// rewritten to NOT EXISTS. Use AntiJoin to ignore NULLs returned by subquery instead.
+func NotInSubquery(col string, subquery *gorm.DB) *gorm.DB {
+	if !subqueryNullable(subquery) {
+		return subquery.Session(&gorm.Session{NewDB: true}).Where("? NOT IN (?)", clause.Column{Name: col}, subquery)
+	}
+	return subquery.Session(&gorm.Session{NewDB: true}).Where(
+		"NOT EXISTS (SELECT 1 FROM (?) AS not_in(not_in_value) WHERE not_in.not_in_value = ? OR not_in.not_in_value IS NULL OR ? IS NULL)",
+		subquery, clause.Column{Name: col}, clause.Column{Name: col},
+	)
+}
+
+// subqueryNullable reports whether the single column selected by subquery may be NULL.
+// Subqueries selecting anything but a single table column, and lookups that fail or don't run,
+// e.g. under DryRun, count as nullable.
+func subqueryNullable(subquery *gorm.DB) bool {
+	stmt := subquery.Statement
+	if len(stmt.Selects) != 1 {
+		return true
+	}
+	if stmt.Table == "" && stmt.Model != nil {
+		if err := stmt.Parse(stmt.Model); err != nil {
+			return true
+		}
+	}
+
+	column := stmt.Selects[0]
+	if idx := strings.LastIndex(column, "."); idx >= 0 {
+		column = column[idx+1:]
+	}
+	if stmt.Schema != nil {
+		if field := stmt.Schema.LookUpField(column); field != nil {
+			column = field.DBName
+		}
+	}
+
+	var schemaName interface{} = clause.Expr{SQL: "current_schema()"}
+	table := stmt.Table
+	if parts, ok := splitQualifiedName(table); ok && len(parts) >= 2 {
+		schemaName, table = parts[len(parts)-2], parts[len(parts)-1]
+	}
+
+	var nullable sql.NullBool
+	err := subquery.Session(&gorm.Session{NewDB: true}).Raw(
+		"SELECT bool_or(is_nullable) FROM duckdb_columns() WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND column_name = ?",
+		schemaName, table, column,
+	).Scan(&nullable).Error
+	return err != nil || !nullable.Valid || nullable.Bool
+}
+
+// DescribeQueryColumn is an output column of a query as reported by DESCRIBE.
+type DescribeQueryColumn struct {
+	ColumnName string
+	ColumnType string
+	Nullable   bool
+}
+
+// DescribeQuery returns the output columns of query with DESCRIBE, which binds the query without executing it.
+func DescribeQuery(db *gorm.DB, query string, args ...interface{}) ([]DescribeQueryColumn, error) {
+	var rows []struct {
+		ColumnName string
+		ColumnType string
+		Null       string
+	}
+	if err := db.Raw("DESCRIBE "+query, args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	columns := make([]DescribeQueryColumn, len(rows))
+	for i, row := range rows {
+		columns[i] = DescribeQueryColumn{
+			ColumnName: row.ColumnName,
+			ColumnType: row.ColumnType,
+			Nullable:   row.Null == "YES",
+		}
+	}
+	return columns, nil
+}
+
+// SelectIntoOption configures SelectInto, fields set in any of the given options apply.
+type SelectIntoOption struct {
+	Temporary   bool
+	IfNotExists bool
+}
+
+// SelectInto creates newTableName from the result of query with
+// CREATE [TEMP] TABLE [IF NOT EXISTS] newTableName AS (query).
+func SelectInto(db *gorm.DB, newTableName string, query *gorm.DB, opts ...SelectIntoOption) error {
+	var opt SelectIntoOption
+	for _, o := range opts {
+		opt.Temporary = opt.Temporary || o.Temporary
+		opt.IfNotExists = opt.IfNotExists || o.IfNotExists
+	}
+
+	sql := "CREATE "
+	if opt.Temporary {
+		sql += "TEMP "
+	}
+	sql += "TABLE "
+	if opt.IfNotExists {
+		sql += "IF NOT EXISTS "
+	}
+
+	// render query on its own statement first, db and query may share one, e.g. inside db.Connection
+	rendered := query.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]interface{}{})
+	if rendered.Error != nil {
+		return rendered.Error
+	}
+	subquery := clause.Expr{SQL: rendered.Statement.SQL.String(), Vars: rendered.Statement.Vars}
+	return db.Session(&gorm.Session{NewDB: true}).Exec(sql+"? AS (?)", clause.Table{Name: newTableName}, subquery).Error
+}
+
+// WhereExists returns a condition for db.Where matching rows for which subquery returns a row,
+// correlate it by referencing the outer table:
+//
+//	db.Model(&User{}).Where(duckdb.WhereExists(db.Table("orders").Where("orders.user_id = users.id")))
+func WhereExists(subquery *gorm.DB) *gorm.DB {
+	return subquery.Session(&gorm.Session{NewDB: true}).Where("EXISTS (?)", subquery)
+}
+
+// WhereNotExists returns a condition for db.Where matching rows for which subquery returns no row.
+func WhereNotExists(subquery *gorm.DB) *gorm.DB {
+	return subquery.Session(&gorm.Session{NewDB: true}).Where("NOT EXISTS (?)", subquery)
+}
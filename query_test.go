@@ -0,0 +1,264 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestUnionByName verifies that columns are aligned by name across differently ordered tables.
+func TestUnionByName(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE union_a (name VARCHAR, price INTEGER)").Error)
+	assert.NoError(t, db.Exec("CREATE TABLE union_b (price INTEGER, name VARCHAR)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO union_a VALUES ('apple', 1)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO union_b VALUES (2, 'banana')").Error)
+
+	var rows []struct {
+		Name  string
+		Price int
+	}
+	err := duckdb.UnionByName(db.Table("union_a"), db.Table("union_b")).Order("price").Find(&rows).Error
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "apple", rows[0].Name)
+	assert.Equal(t, 1, rows[0].Price)
+	assert.Equal(t, "banana", rows[1].Name)
+	assert.Equal(t, 2, rows[1].Price)
+
+	assert.Nil(t, duckdb.UnionByName())
+}
+
+// TestWhereInList verifies both the IN list and the unnest form select the same rows.
+func TestWhereInList(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 20000)
+
+	ids := make([]int, 10000)
+	for i := range ids {
+		ids[i] = i * 2
+	}
+
+	var count int64
+	assert.NoError(t, duckdb.WhereInList(db.Model(&SampleRow{}), "id", ids).Count(&count).Error)
+	assert.Equal(t, int64(10000), count)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return duckdb.WhereInList(tx.Model(&SampleRow{}), "id", ids).Count(&count)
+	})
+	assert.Contains(t, sql, "unnest")
+
+	threshold := duckdb.InListUnnestThreshold
+	duckdb.InListUnnestThreshold = len(ids)
+	defer func() { duckdb.InListUnnestThreshold = threshold }()
+
+	assert.NoError(t, duckdb.WhereInList(db.Model(&SampleRow{}), "id", ids).Count(&count).Error)
+	assert.Equal(t, int64(10000), count)
+
+	names := []string{"a", "b"}
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return duckdb.WhereInList(tx.Model(&SampleRow{}), "id", names).Count(&count)
+	})
+	assert.NotContains(t, sql, "unnest")
+}
+
+// TestWhereInListUnsigned verifies unsigned values above math.MaxInt64 match in the unnest form.
+func TestWhereInListUnsigned(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE big_ids (id UBIGINT)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO big_ids VALUES (1), (18446744073709551615)").Error)
+
+	threshold := duckdb.InListUnnestThreshold
+	duckdb.InListUnnestThreshold = 1
+	defer func() { duckdb.InListUnnestThreshold = threshold }()
+
+	var ids []uint64
+	assert.NoError(t, duckdb.WhereInList(db.Table("big_ids"), "id", []uint64{math.MaxUint64, 2}).Pluck("id", &ids).Error)
+	assert.Equal(t, []uint64{math.MaxUint64}, ids)
+}
+
+func BenchmarkWhereInList(b *testing.B) {
+	db, err := gorm.Open(duckdb.Open(""), &gorm.Config{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err = db.Exec("CREATE TABLE sample_rows AS SELECT range::INTEGER AS id FROM range(100000)").Error; err != nil {
+		b.Fatal(err)
+	}
+
+	ids := make([]int, 10000)
+	for i := range ids {
+		ids[i] = i * 7
+	}
+
+	threshold := duckdb.InListUnnestThreshold
+	defer func() { duckdb.InListUnnestThreshold = threshold }()
+
+	for name, limit := range map[string]int{"in": len(ids), "unnest": 0} {
+		b.Run(name, func(b *testing.B) {
+			duckdb.InListUnnestThreshold = limit
+			for i := 0; i < b.N; i++ {
+				var count int64
+				if err := duckdb.WhereInList(db.Model(&SampleRow{}), "id", ids).Count(&count).Error; err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestNotInSubquery verifies the NOT EXISTS rewrite returns the same rows as NOT IN, with and without NULLs.
+func TestNotInSubquery(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE customers (id INTEGER)").Error)
+	assert.NoError(t, db.Exec("CREATE TABLE orders (customer_id INTEGER)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO customers VALUES (1), (2), (3)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO orders VALUES (1), (1)").Error)
+
+	query := func() (notIn, antiJoin []int) {
+		assert.NoError(t, db.Raw("SELECT id FROM customers WHERE id NOT IN (SELECT customer_id FROM orders) ORDER BY id").Scan(&notIn).Error)
+		assert.NoError(t, db.Table("customers").
+			Where(duckdb.NotInSubquery("customers.id", db.Table("orders").Select("customer_id"))).
+			Order("id").Pluck("id", &antiJoin).Error)
+		return
+	}
+
+	notIn, antiJoin := query()
+	assert.Equal(t, []int{2, 3}, notIn)
+	assert.Equal(t, notIn, antiJoin)
+
+	// a NULL in the subquery makes NOT IN unknown for every row
+	assert.NoError(t, db.Exec("INSERT INTO orders VALUES (NULL)").Error)
+	notIn, antiJoin = query()
+	assert.Empty(t, notIn)
+	assert.Empty(t, antiJoin)
+
+	// a NOT NULL subquery column keeps plain NOT IN, also when rendered with ToSQL
+	assert.NoError(t, db.Exec("CREATE TABLE members (id INTEGER NOT NULL)").Error)
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("customers").Where(duckdb.NotInSubquery("id", db.Table("members").Select("id"))).Find(&[]map[string]interface{}{})
+	})
+	assert.Contains(t, sql, "NOT IN")
+	assert.NotContains(t, sql, "NOT EXISTS")
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("customers").Where(duckdb.NotInSubquery("id", tx.Table("members").Select("id"))).Find(&[]map[string]interface{}{})
+	})
+	assert.Contains(t, sql, "NOT EXISTS")
+}
+
+// TestDescribeQuery verifies the names and types of computed columns.
+func TestDescribeQuery(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE priced (name VARCHAR NOT NULL, price DOUBLE)").Error)
+
+	columns, err := duckdb.DescribeQuery(db, "SELECT name, price * 1.1 AS price_with_tax, count(*) OVER () AS total FROM priced")
+	assert.NoError(t, err)
+	assert.Len(t, columns, 3)
+
+	expected := map[string]string{"name": "VARCHAR", "price_with_tax": "DOUBLE", "total": "BIGINT"}
+	for _, column := range columns {
+		assert.Equal(t, expected[column.ColumnName], column.ColumnType, column.ColumnName)
+	}
+	assert.Equal(t, "price_with_tax", columns[1].ColumnName)
+	assert.True(t, columns[1].Nullable)
+
+	_, err = duckdb.DescribeQuery(db, "SELECT missing FROM priced")
+	assert.Error(t, err)
+}
+
+// TestSelectInto verifies the new table holds the filtered rows with the query's columns.
+func TestSelectInto(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Product{}))
+	assert.NoError(t, db.Create(&[]Product{{Name: "pen", Price: 1}, {Name: "ink", Price: 3}, {Name: "pad", Price: 5}}).Error)
+
+	query := db.Model(&Product{}).Select("name", "price").Where("price > ?", 2)
+	assert.NoError(t, duckdb.SelectInto(db, "expensive_products", query))
+	assert.True(t, db.Migrator().HasTable("expensive_products"))
+
+	var count int64
+	assert.NoError(t, db.Table("expensive_products").Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	columnTypes, err := db.Migrator().ColumnTypes("expensive_products")
+	assert.NoError(t, err)
+	names := make([]string, len(columnTypes))
+	for i, columnType := range columnTypes {
+		names[i] = columnType.Name()
+	}
+	assert.Equal(t, []string{"name", "price"}, names)
+
+	assert.Error(t, duckdb.SelectInto(db, "expensive_products", query))
+	assert.NoError(t, duckdb.SelectInto(db, "expensive_products", query, duckdb.SelectIntoOption{IfNotExists: true}))
+
+	assert.NoError(t, db.Connection(func(tx *gorm.DB) error {
+		assert.NoError(t, duckdb.SelectInto(tx, "cheap_products", tx.Model(&Product{}).Where("price < ?", 2), duckdb.SelectIntoOption{Temporary: true}))
+		var temporary bool
+		assert.NoError(t, tx.Raw("SELECT temporary FROM duckdb_tables() WHERE table_name = ?", "cheap_products").Row().Scan(&temporary))
+		assert.True(t, temporary)
+		return nil
+	}))
+}
+
+// TestWhereExists verifies EXISTS and NOT EXISTS split users by whether they have orders.
+func TestWhereExists(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&User{}))
+	users := []User{{Name: "alice", Email: "alice@example.com"}, {Name: "bob", Email: "bob@example.com"}, {Name: "carol", Email: "carol@example.com"}}
+	assert.NoError(t, db.Create(&users).Error)
+
+	assert.NoError(t, db.Exec("CREATE TABLE user_orders (user_id INTEGER, amount INTEGER)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO user_orders VALUES (?, 10), (?, 20), (?, 5)", users[0].ID, users[0].ID, users[2].ID).Error)
+
+	orders := func(minAmount int) *gorm.DB {
+		return db.Table("user_orders").Where("user_orders.user_id = users.id AND user_orders.amount >= ?", minAmount)
+	}
+
+	var names []string
+	assert.NoError(t, db.Model(&User{}).Where(duckdb.WhereExists(orders(1))).Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"alice", "carol"}, names)
+
+	assert.NoError(t, db.Model(&User{}).Where(duckdb.WhereNotExists(orders(1))).Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"bob"}, names)
+
+	assert.NoError(t, db.Model(&User{}).Where(duckdb.WhereExists(orders(15))).Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"alice"}, names)
+
+	var count int64
+	assert.NoError(t, db.Model(&User{}).Where(duckdb.WhereNotExists(orders(15))).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
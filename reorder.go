@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"gorm.io/gorm/schema"
+)
+
+var ErrCyclicDependency = errors.New("cyclic foreign key dependency")
+
+// TopologicalSort orders models so that every model comes after the models its foreign keys reference,
+// whichever side declares the relationship. Independent models keep their relative order.
+// It returns ErrCyclicDependency when the foreign keys form a cycle.
+func TopologicalSort(models []interface{}) ([]interface{}, error) {
+	var (
+		cache   = &sync.Map{}
+		schemas = make([]*schema.Schema, len(models))
+		index   = make(map[reflect.Type]int, len(models))
+	)
+	for i, model := range models {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			return nil, err
+		}
+		schemas[i] = s
+		index[s.ModelType] = i
+	}
+
+	depends := make([][]int, len(models))
+	for _, s := range schemas {
+		for _, rel := range s.Relationships.Relations {
+			c := rel.ParseConstraint()
+			if c == nil || c.Schema == c.ReferenceSchema {
+				continue
+			}
+			from, ok := index[c.Schema.ModelType]
+			if !ok {
+				continue
+			}
+			if to, ok := index[c.ReferenceSchema.ModelType]; ok {
+				depends[from] = append(depends[from], to)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		states = make([]int, len(models))
+		sorted = make([]interface{}, 0, len(models))
+		visit  func(i int) error
+	)
+	visit = func(i int) error {
+		switch states[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrCyclicDependency, schemas[i].Table)
+		}
+
+		states[i] = visiting
+		for _, dep := range depends[i] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		states[i] = visited
+		sorted = append(sorted, models[i])
+		return nil
+	}
+
+	for i := range models {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
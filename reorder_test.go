@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type Author struct {
+	ID    uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Name  string `gorm:"column:name"`
+	Books []Book
+}
+
+type Book struct {
+	ID       uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Title    string `gorm:"column:title"`
+	AuthorID uint   `gorm:"column:author_id"`
+}
+
+type Review struct {
+	ID     uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Text   string `gorm:"column:text"`
+	BookID uint   `gorm:"column:book_id"`
+	Book   Book
+}
+
+type CycleA struct {
+	ID       uint `gorm:"column:id;primaryKey"`
+	CycleBID uint `gorm:"column:cycle_b_id"`
+	CycleB   *CycleB
+}
+
+type CycleB struct {
+	ID       uint `gorm:"column:id;primaryKey"`
+	CycleAID uint `gorm:"column:cycle_a_id"`
+	CycleA   *CycleA
+}
+
+// TestTopologicalSort verifies models given in reverse dependency order are sorted so each table can be created in turn.
+func TestTopologicalSort(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	sorted, err := duckdb.TopologicalSort([]interface{}{&Review{}, &Book{}, &Author{}})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{&Author{}, &Book{}, &Review{}}, sorted)
+
+	for _, model := range sorted {
+		assert.NoError(t, db.Migrator().CreateTable(model))
+	}
+	assert.True(t, db.Migrator().HasTable(&Review{}))
+
+	_, err = duckdb.TopologicalSort([]interface{}{&CycleA{}, &CycleB{}})
+	assert.ErrorIs(t, err, duckdb.ErrCyclicDependency)
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// SetSchema switches the default schema with SET schema, so unqualified tables,
+// including those created by the Migrator, resolve to schemaName.
+// The setting belongs to the connection running it, use it inside db.Connection
+// or with a pool limited to one connection.
+func SetSchema(db *gorm.DB, schemaName string) error {
+	var count int64
+	if err := db.Raw("SELECT count(*) FROM information_schema.schemata WHERE schema_name = ?", schemaName).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", ErrSchemaNotFound, schemaName)
+	}
+
+	return db.Exec("SET schema = ?", stringLiteral(schemaName)).Error
+}
+
+// GetCurrentSchema returns the default schema of the connection.
+func GetCurrentSchema(db *gorm.DB) (schemaName string, err error) {
+	err = db.Raw("SELECT current_schema()").Row().Scan(&schemaName)
+	return
+}
+
+// GrantSchemaUsage would grant user USAGE on schemaName. DuckDB is an embedded database
+// without users or privileges and has no GRANT statement, so it returns ErrDuckDBNotSupported;
+// restrict access through file permissions or by attaching databases READ_ONLY instead.
+func GrantSchemaUsage(db *gorm.DB, schemaName, user string) error {
+	return fmt.Errorf("%w: GRANT USAGE ON SCHEMA %s TO %s", ErrDuckDBNotSupported, schemaName, user)
+}
+
+// RevokeSchemaUsage would revoke USAGE on schemaName from user, see GrantSchemaUsage.
+func RevokeSchemaUsage(db *gorm.DB, schemaName, user string) error {
+	return fmt.Errorf("%w: REVOKE USAGE ON SCHEMA %s FROM %s", ErrDuckDBNotSupported, schemaName, user)
+}
+
+// GrantAllOnTable would grant user all privileges on tableName, see GrantSchemaUsage.
+func GrantAllOnTable(db *gorm.DB, tableName, user string) error {
+	return fmt.Errorf("%w: GRANT ALL ON %s TO %s", ErrDuckDBNotSupported, tableName, user)
+}
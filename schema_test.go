@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+func tableSchemas(t *testing.T, db *gorm.DB, table string) []string {
+	var schemas []string
+	assert.NoError(t, db.Raw("SELECT table_schema FROM information_schema.tables WHERE table_name = ? ORDER BY table_schema", table).
+		Scan(&schemas).Error)
+	return schemas
+}
+
+// TestSetSchema verifies tables are created in the schema selected with SetSchema.
+func TestSetSchema(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	// SET schema is connection scoped
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	current, err := duckdb.GetCurrentSchema(db)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", current)
+
+	assert.NoError(t, db.Exec("CREATE SCHEMA sales").Error)
+	assert.NoError(t, db.Exec("CREATE SCHEMA finance").Error)
+
+	assert.NoError(t, duckdb.SetSchema(db, "sales"))
+	assert.NoError(t, db.AutoMigrate(&Product{}))
+	assert.Equal(t, []string{"sales"}, tableSchemas(t, db, "products"))
+
+	m := db.Migrator().(duckdb.Migrator)
+	assert.NoError(t, m.SetSchema("finance"))
+	current, err = m.GetCurrentSchema()
+	assert.NoError(t, err)
+	assert.Equal(t, "finance", current)
+
+	assert.False(t, m.HasTable(&Product{}))
+	assert.NoError(t, m.CreateTable(&Product{}))
+	assert.True(t, m.HasTable(&Product{}))
+	assert.Equal(t, []string{"finance", "sales"}, tableSchemas(t, db, "products"))
+
+	assert.ErrorIs(t, duckdb.SetSchema(db, "missing"), duckdb.ErrSchemaNotFound)
+	assert.NoError(t, duckdb.SetSchema(db, "main"))
+}
+
+// TestSchemaGrants verifies grants report ErrDuckDBNotSupported, as DuckDB has no users or privileges.
+func TestSchemaGrants(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.ErrorIs(t, duckdb.GrantSchemaUsage(db, "main", "analyst"), duckdb.ErrDuckDBNotSupported)
+	assert.ErrorIs(t, duckdb.RevokeSchemaUsage(db, "main", "analyst"), duckdb.ErrDuckDBNotSupported)
+	assert.ErrorIs(t, duckdb.GrantAllOnTable(db, "products", "analyst"), duckdb.ErrDuckDBNotSupported)
+}
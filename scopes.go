@@ -0,0 +1,222 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidPeriod  = errors.New("invalid time bucket period")
+	ErrInvalidThreads = errors.New("threads must be positive")
+	ErrInvalidSample  = errors.New("invalid sample method")
+)
+
+type SampleMethod string
+
+const (
+	SampleBernoulli SampleMethod = "bernoulli"
+	SampleSystem    SampleMethod = "system"
+	SampleReservoir SampleMethod = "reservoir"
+)
+
+// SampleClause is DuckDB's USING SAMPLE clause, written between GROUP BY and ORDER BY.
+// https://duckdb.org/docs/sql/samples.html
+type SampleClause struct {
+	Method SampleMethod
+	Size   float64
+	Unit   string // PERCENT or ROWS
+	Seed   *int64
+}
+
+func (s SampleClause) Name() string {
+	return "USING SAMPLE"
+}
+
+func (s SampleClause) Build(builder clause.Builder) {
+	size := strconv.FormatFloat(s.Size, 'f', -1, 64) + " " + s.Unit
+	if s.Method != "" {
+		size = string(s.Method) + "(" + size + ")"
+	}
+	_, _ = builder.WriteString(size)
+
+	if s.Seed != nil {
+		_, _ = builder.WriteString(" REPEATABLE (")
+		_, _ = builder.WriteString(strconv.FormatInt(*s.Seed, 10))
+		_ = builder.WriteByte(')')
+	}
+}
+
+func (s SampleClause) MergeClause(c *clause.Clause) {
+	c.Expression = s
+}
+
+// RepeatableSample samples percent of the rows with method, returning the same rows for the same seed.
+// DuckDB only guarantees this while the scan runs on a single thread, i.e. for small tables or with SET threads = 1.
+func RepeatableSample(percent float64, seed int64, method SampleMethod) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Clauses(SampleClause{Method: method, Size: percent, Unit: "PERCENT", Seed: &seed})
+	}
+}
+
+// Sample keeps a random sample of the rows with USING SAMPLE, method is one of:
+//
+//   - PERCENT samples n percent of the rows, each row is picked independently (bernoulli)
+//   - ROWS samples exactly n rows, or all rows of smaller results (reservoir)
+//   - SYSTEM samples n percent of the rows in chunks of about 2048 rows, faster but coarse on small tables
+func Sample(n float64, method string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch strings.ToUpper(method) {
+		case "PERCENT":
+			return db.Clauses(SampleClause{Method: SampleBernoulli, Size: n, Unit: "PERCENT"})
+		case "ROWS":
+			return db.Clauses(SampleClause{Method: SampleReservoir, Size: n, Unit: "ROWS"})
+		case "SYSTEM":
+			return db.Clauses(SampleClause{Method: SampleSystem, Size: n, Unit: "PERCENT"})
+		}
+		_ = db.AddError(fmt.Errorf("%w: %s", ErrInvalidSample, method))
+		return db
+	}
+}
+
+// LateralUnnest joins each row with the elements of its list column arrayCol, exposed as column aliasCol:
+//
+//	FROM t CROSS JOIN LATERAL (SELECT unnest(arrayCol) AS aliasCol) AS aliasCol_unnest
+//
+// Applying it again to aliasCol flattens one more level of a nested list.
+// Rows whose list is empty or NULL are dropped.
+func LateralUnnest(arrayCol, aliasCol string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins("CROSS JOIN LATERAL (SELECT unnest(?) AS ?) AS ?",
+			clause.Column{Name: arrayCol}, clause.Column{Name: aliasCol}, clause.Table{Name: aliasCol + "_unnest"})
+	}
+}
+
+var timeBucketPeriods = map[string]bool{
+	"second": true, "minute": true, "hour": true, "day": true,
+	"week": true, "month": true, "quarter": true, "year": true,
+}
+
+// GroupByTimeBucket groups rows by DATE_TRUNC(period, col) and selects it as time_bucket,
+// in front of the columns already selected:
+//
+//	db.Model(&Event{}).Select("count(*) AS total").Scopes(duckdb.GroupByTimeBucket("created_at", "hour"))
+//
+// period is one of second, minute, hour, day, week, month, quarter and year, col a column name,
+// optionally qualified with its table.
+func GroupByTimeBucket(col, period string) func(*gorm.DB) *gorm.DB {
+	period = strings.ToLower(period)
+	return func(db *gorm.DB) *gorm.DB {
+		if !timeBucketPeriods[period] {
+			_ = db.AddError(fmt.Errorf("%w: %s", ErrInvalidPeriod, period))
+			return db
+		}
+		parts, ok := splitQualifiedName(col)
+		if !ok {
+			_ = db.AddError(fmt.Errorf("%w: %q", ErrInvalidIdentifier, col))
+			return db
+		}
+		for _, part := range parts {
+			if err := validIdentifier(part); err != nil {
+				_ = db.AddError(err)
+				return db
+			}
+		}
+
+		bucket := "DATE_TRUNC('" + period + "', " + col + ")"
+		if c, ok := db.Statement.Clauses["SELECT"]; ok && c.Expression != nil {
+			db.Statement.AddClause(clause.Select{
+				Distinct:   db.Statement.Distinct,
+				Expression: clause.Expr{SQL: bucket + " AS time_bucket, ?", Vars: []interface{}{c.Expression}},
+			})
+		} else {
+			db.Statement.Selects = append([]string{bucket + " AS time_bucket"}, db.Statement.Selects...)
+		}
+		return db.Group(bucket)
+	}
+}
+
+// AntiJoin keeps the rows without a match in joinTable, with DuckDB's ANTI JOIN:
+//
+//	db.Model(&Customer{}).Scopes(duckdb.AntiJoin("orders", "orders.customer_id = customers.id"))
+//
+// It is the same as WHERE NOT EXISTS (SELECT 1 FROM joinTable WHERE on), written as a join.
+func AntiJoin(joinTable, on string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins("ANTI JOIN ? ON "+on, clause.Table{Name: joinTable})
+	}
+}
+
+// FromParquet queries the Parquet file at filePath instead of the table of the model:
+//
+//	db.Scopes(duckdb.FromParquet("data.parquet")).Where("price > ?", 100).Find(&results)
+//
+// filePath may also be a glob like data/*.parquet. The file is read as is, the model only
+// maps its columns, so no table has to be migrated.
+func FromParquet(filePath string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Table("read_parquet(?)", filePath)
+	}
+}
+
+// FromCSV queries the CSV file at filePath instead of the table of the model, like FromParquet:
+//
+//	db.Scopes(duckdb.FromCSV("data.csv", duckdb.CSVOptions{Header: true})).Select("name, age").Find(&rows)
+//
+// Options left empty are detected from the file by read_csv_auto.
+func FromCSV(filePath string, opts CSVOptions) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		sql := "read_csv_auto(?"
+		for _, option := range opts.options() {
+			sql += ", " + option[0] + " = " + option[1]
+		}
+		return db.Table(sql+")", filePath)
+	}
+}
+
+// WithPrefetch enables prefetching for scans before the query runs, with
+// SET prefetch_all_parquet_files = true, so sequential scans of Parquet files read ahead.
+// DuckDB keeps the setting for the database afterwards, not just for the query.
+func WithPrefetch(db *gorm.DB) *gorm.DB {
+	return applySetting(db, "prefetch_all_parquet_files", "true")
+}
+
+// WithThreads runs SET threads = n before the query. DuckDB keeps the setting
+// for the database afterwards, not just for the query.
+func WithThreads(n int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if n < 1 {
+			_ = db.AddError(fmt.Errorf("%w: %d", ErrInvalidThreads, n))
+			return db
+		}
+		return applySetting(db, "threads", strconv.Itoa(n))
+	}
+}
+
+func applySetting(db *gorm.DB, name, value string) *gorm.DB {
+	if err := db.Session(&gorm.Session{NewDB: true}).Exec("SET " + name + " = " + value).Error; err != nil {
+		_ = db.AddError(err)
+	}
+	return db
+}
@@ -0,0 +1,236 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+type SampleRow struct {
+	ID int `gorm:"column:id"`
+}
+
+func initSampleRows(t *testing.T, db *gorm.DB, n int) {
+	assert.NoError(t, db.Exec(fmt.Sprintf("CREATE TABLE sample_rows AS SELECT range::INTEGER AS id FROM range(%d)", n)).Error)
+}
+
+// TestRepeatableSample verifies the same seed returns the same rows and a different seed different ones.
+func TestRepeatableSample(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 1000)
+
+	sample := func(seed int64) []int {
+		var ids []int
+		err := db.Model(&SampleRow{}).Scopes(duckdb.RepeatableSample(10, seed, duckdb.SampleBernoulli)).
+			Order("id").Pluck("id", &ids).Error
+		assert.NoError(t, err)
+		return ids
+	}
+
+	first := sample(42)
+	assert.NotEmpty(t, first)
+	assert.Less(t, len(first), 1000)
+	assert.Equal(t, first, sample(42))
+	assert.NotEqual(t, first, sample(7))
+}
+
+// TestSample verifies percent samples return roughly the requested share and row samples the exact count.
+func TestSample(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 1000)
+
+	var count int64
+	assert.NoError(t, db.Model(&SampleRow{}).Scopes(duckdb.Sample(50, "PERCENT")).Count(&count).Error)
+	assert.InDelta(t, 500, count, 100)
+
+	var ids []int
+	assert.NoError(t, db.Model(&SampleRow{}).Scopes(duckdb.Sample(25, "ROWS")).Pluck("id", &ids).Error)
+	assert.Len(t, ids, 25)
+
+	err := db.Model(&SampleRow{}).Scopes(duckdb.Sample(10, "BLOCKS")).Pluck("id", &ids).Error
+	assert.ErrorIs(t, err, duckdb.ErrInvalidSample)
+}
+
+// TestLateralUnnest verifies two lateral unnests fully flatten a nested list column.
+func TestLateralUnnest(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE grids (id INTEGER, matrix VARCHAR[][])").Error)
+	assert.NoError(t, db.Exec("INSERT INTO grids VALUES (1, [['a', 'b'], ['c']]), (2, [['d']]), (3, [])").Error)
+
+	var rows []struct {
+		ID   int
+		Item string
+	}
+	err := db.Table("grids").
+		Scopes(duckdb.LateralUnnest("matrix", "row_items"), duckdb.LateralUnnest("row_items", "item")).
+		Select("id, item").Order("id, item").Find(&rows).Error
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+
+	items := make([]string, len(rows))
+	for i, row := range rows {
+		items[i] = row.Item
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, items)
+	assert.Equal(t, 2, rows[3].ID)
+}
+
+// TestGroupByTimeBucket verifies the row count of each hourly bucket.
+func TestGroupByTimeBucket(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE readings (taken_at TIMESTAMP, value INTEGER)").Error)
+	assert.NoError(t, db.Exec(`INSERT INTO readings VALUES
+		('2024-01-01 10:05:00', 1), ('2024-01-01 10:59:59', 2), ('2024-01-01 11:00:00', 3),
+		('2024-01-01 13:30:00', 4), ('2024-01-01 13:31:00', 5), ('2024-01-01 13:45:00', 6)`).Error)
+
+	var buckets []struct {
+		TimeBucket time.Time
+		Total      int
+	}
+	err := db.Table("readings").Select("count(*) AS total").
+		Scopes(duckdb.GroupByTimeBucket("taken_at", "hour")).Order("time_bucket").Find(&buckets).Error
+	assert.NoError(t, err)
+	assert.Len(t, buckets, 3)
+
+	expected := map[int]int{10: 2, 11: 1, 13: 3}
+	for _, bucket := range buckets {
+		assert.Equal(t, 0, bucket.TimeBucket.Minute())
+		assert.Equal(t, expected[bucket.TimeBucket.Hour()], bucket.Total, bucket.TimeBucket)
+	}
+
+	var sums []struct {
+		TimeBucket time.Time
+		Total      int
+	}
+	err = db.Table("readings").Select("CAST(sum(value) FILTER (WHERE value > ?) AS INTEGER) AS total", 1).
+		Scopes(duckdb.GroupByTimeBucket("taken_at", "DAY")).Find(&sums).Error
+	assert.NoError(t, err)
+	assert.Len(t, sums, 1)
+	assert.Equal(t, 20, sums[0].Total)
+
+	err = db.Table("readings").Scopes(duckdb.GroupByTimeBucket("taken_at", "fortnight")).Find(&buckets).Error
+	assert.ErrorIs(t, err, duckdb.ErrInvalidPeriod)
+
+	err = db.Table("readings").Scopes(duckdb.GroupByTimeBucket("readings.taken_at", "hour")).Find(&buckets).Error
+	assert.NoError(t, err)
+	err = db.Table("readings").Scopes(duckdb.GroupByTimeBucket("taken_at); DROP TABLE readings; --", "hour")).Find(&buckets).Error
+	assert.ErrorIs(t, err, duckdb.ErrInvalidIdentifier)
+}
+
+// TestSettingScopes verifies the prefetch and threads scopes apply their settings.
+func TestSettingScopes(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&PoolItem{}))
+
+	var items []PoolItem
+	assert.NoError(t, db.Scopes(duckdb.WithPrefetch, duckdb.WithThreads(2)).Find(&items).Error)
+
+	var prefetch, threads string
+	assert.NoError(t, db.Raw("SELECT value FROM duckdb_settings() WHERE name = ?", "prefetch_all_parquet_files").Row().Scan(&prefetch))
+	assert.Equal(t, "true", prefetch)
+	assert.NoError(t, db.Raw("SELECT value FROM duckdb_settings() WHERE name = ?", "threads").Row().Scan(&threads))
+	assert.Equal(t, "2", threads)
+
+	assert.ErrorIs(t, db.Scopes(duckdb.WithThreads(0)).Find(&items).Error, duckdb.ErrInvalidThreads)
+}
+
+// TestAntiJoin verifies AntiJoin keeps the customers without orders, even when order customer ids
+// contain NULL, where NOT IN matches no customer at all.
+func TestAntiJoin(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE customers (id INTEGER NOT NULL, name VARCHAR)").Error)
+	assert.NoError(t, db.Exec("CREATE TABLE orders (id INTEGER NOT NULL, customer_id INTEGER)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO customers VALUES (1, 'ann'), (2, 'bob'), (3, 'cid')").Error)
+	assert.NoError(t, db.Exec("INSERT INTO orders VALUES (1, 1), (2, 1), (3, NULL)").Error)
+
+	var names []string
+	err := db.Table("customers").Scopes(duckdb.AntiJoin("orders", "orders.customer_id = customers.id")).
+		Order("name").Pluck("name", &names).Error
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob", "cid"}, names)
+
+	var naive []string
+	err = db.Table("customers").Where("id NOT IN (?)", db.Table("orders").Select("customer_id")).Pluck("name", &naive).Error
+	assert.NoError(t, err)
+	assert.Empty(t, naive)
+}
+
+type ParquetPrice struct {
+	Name  string  `gorm:"column:name"`
+	Price float64 `gorm:"column:price"`
+}
+
+// TestFromParquet verifies a Parquet file is queried in place with conditions, without a backing table.
+func TestFromParquet(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	file := filepath.Join(t.TempDir(), "prices.parquet")
+	assert.NoError(t, db.Exec("COPY (SELECT * FROM (VALUES ('pen', 2.5), ('desk', 150.0), ('lamp', 120.0)) AS t(name, price)) TO '"+file+"' (FORMAT PARQUET)").Error)
+
+	var prices []ParquetPrice
+	assert.NoError(t, db.Scopes(duckdb.FromParquet(file)).Where("price > ?", 100).Order("price").Find(&prices).Error)
+	assert.Equal(t, []ParquetPrice{{Name: "lamp", Price: 120}, {Name: "desk", Price: 150}}, prices)
+	assert.False(t, db.Migrator().HasTable(&ParquetPrice{}))
+}
+
+type CSVPerson struct {
+	Name string `gorm:"column:name"`
+	Age  *int   `gorm:"column:age"`
+}
+
+// TestFromCSV verifies a CSV file is queried in place with its dialect options, quoting included.
+func TestFromCSV(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	file := filepath.Join(t.TempDir(), "it's people.csv")
+	assert.NoError(t, os.WriteFile(file, []byte("name;age\nann;31\nbob;-\no'neil;45\n"), 0o600))
+
+	var people []CSVPerson
+	opts := duckdb.CSVOptions{Header: true, Delimiter: ";", NullString: "-"}
+	assert.NoError(t, db.Scopes(duckdb.FromCSV(file, opts)).Select("name, age").Order("name").Find(&people).Error)
+	assert.Len(t, people, 3)
+	assert.Equal(t, "ann", people[0].Name)
+	assert.Equal(t, 31, *people[0].Age)
+	assert.Nil(t, people[1].Age)
+	assert.Equal(t, "o'neil", people[2].Name)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Scopes(duckdb.FromCSV(file, duckdb.CSVOptions{Delimiter: "'"})).Find(&[]CSVPerson{})
+	})
+	assert.Contains(t, sql, "delim = ''''")
+}
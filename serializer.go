@@ -0,0 +1,271 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+func init() {
+	schema.RegisterSerializer("struct", StructSerializer{})
+	schema.RegisterSerializer("timezone", TimezoneSerializer{})
+	schema.RegisterSerializer("mask_email", MaskingSerializer{Mask: MaskEmail})
+	schema.RegisterSerializer("mask_cc", MaskingSerializer{Mask: MaskCreditCard})
+}
+
+// StructSerializer stores a nested Go struct in a single DuckDB STRUCT column, e.g.
+//
+//	Address Address `gorm:"type:struct(street varchar, city varchar);serializer:struct"`
+//
+// Struct fields are mapped to STRUCT fields by their json names.
+type StructSerializer struct{}
+
+// Scan implements serializer interface
+func (StructSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) (err error) {
+	fieldValue := reflect.New(field.FieldType)
+
+	if dbValue != nil {
+		var data []byte
+		switch v := dbValue.(type) {
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		default:
+			if data, err = json.Marshal(v); err != nil {
+				return err
+			}
+		}
+
+		if len(data) > 0 {
+			if err = json.Unmarshal(data, fieldValue.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return
+}
+
+// Value implements serializer interface, it renders the struct as a DuckDB STRUCT literal
+// such as {'street': 'Main St', 'zip': 12345}, which DuckDB casts to the column type.
+func (StructSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	data, err := json.Marshal(fieldValue)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err = decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	var builder strings.Builder
+	writeStructLiteral(&builder, value)
+	return builder.String(), nil
+}
+
+func writeStructLiteral(builder *strings.Builder, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		builder.WriteString("NULL")
+	case bool, json.Number:
+		builder.WriteString(fmt.Sprint(v))
+	case string:
+		builder.WriteString(quoteStructString(v))
+	case []interface{}:
+		builder.WriteByte('[')
+		for idx, elem := range v {
+			if idx > 0 {
+				builder.WriteString(", ")
+			}
+			writeStructLiteral(builder, elem)
+		}
+		builder.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		builder.WriteByte('{')
+		for idx, key := range keys {
+			if idx > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(quoteStructString(key))
+			builder.WriteString(": ")
+			writeStructLiteral(builder, v[key])
+		}
+		builder.WriteByte('}')
+	}
+}
+
+func quoteStructString(str string) string {
+	str = strings.ReplaceAll(str, `\`, `\\`)
+	return "'" + strings.ReplaceAll(str, "'", `\'`) + "'"
+}
+
+// TimezoneSerializer stores time.Time and *time.Time fields in UTC and reads them back
+// in Location, or the location named by the field's timezone tag, e.g.
+//
+//	ShippedAt time.Time `gorm:"serializer:timezone" timezone:"America/Los_Angeles"`
+//
+// It reads in time.Local when neither is set.
+type TimezoneSerializer struct {
+	Location *time.Location
+}
+
+func (s TimezoneSerializer) location(field *schema.Field) (*time.Location, error) {
+	if name := field.Tag.Get("timezone"); name != "" {
+		return time.LoadLocation(name)
+	}
+	if s.Location != nil {
+		return s.Location, nil
+	}
+	return time.Local, nil
+}
+
+// Scan implements serializer interface
+func (s TimezoneSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType).Elem()
+
+	if dbValue != nil {
+		t, ok := dbValue.(time.Time)
+		if !ok {
+			return fmt.Errorf("failed to scan %T into time.Time", dbValue)
+		}
+
+		loc, err := s.location(field)
+		if err != nil {
+			return err
+		}
+		t = t.In(loc)
+
+		if fieldValue.Kind() == reflect.Ptr {
+			fieldValue.Set(reflect.ValueOf(&t))
+		} else {
+			fieldValue.Set(reflect.ValueOf(t))
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value implements serializer interface
+func (TimezoneSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	switch v := fieldValue.(type) {
+	case time.Time:
+		return v.UTC(), nil
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		return v.UTC(), nil
+	default:
+		return nil, fmt.Errorf("invalid field type %T for timezone serializer, only time.Time and *time.Time are supported", fieldValue)
+	}
+}
+
+// MaskingSerializer masks string and *string fields when they are read, values are written unchanged,
+// so a masked model must not save what it read back:
+//
+//	Email string `gorm:"serializer:mask_email"` // alice@example.com reads as a***@example.com
+//	Card  string `gorm:"serializer:mask_cc"`    // 4111-1111-1111-1234 reads as ****-****-****-1234
+type MaskingSerializer struct {
+	Mask func(string) string
+}
+
+// Scan implements serializer interface
+func (s MaskingSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType).Elem()
+
+	if dbValue != nil {
+		var str string
+		switch v := dbValue.(type) {
+		case string:
+			str = v
+		case []byte:
+			str = string(v)
+		default:
+			return fmt.Errorf("failed to scan %T into string", dbValue)
+		}
+
+		masked := s.Mask(str)
+		if fieldValue.Kind() == reflect.Ptr {
+			fieldValue.Set(reflect.ValueOf(&masked))
+		} else {
+			fieldValue.SetString(masked)
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value implements serializer interface
+func (MaskingSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	if v, ok := fieldValue.(*string); ok {
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	}
+	return fieldValue, nil
+}
+
+// MaskEmail keeps the first letter and the domain of an email, e.g. a***@example.com.
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// MaskCreditCard keeps the last four digits of a card number, e.g. ****-****-****-1234.
+func MaskCreditCard(number string) string {
+	var digits []byte
+	for i := 0; i < len(number); i++ {
+		if number[i] >= '0' && number[i] <= '9' {
+			digits = append(digits, number[i])
+		}
+	}
+	if len(digits) < 4 {
+		return "****"
+	}
+	return "****-****-****-" + string(digits[len(digits)-4:])
+}
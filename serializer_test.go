@@ -0,0 +1,179 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type Address struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type CustomerWithAddress struct {
+	ID      uint    `gorm:"column:id;primaryKey;autoIncrement"`
+	Name    string  `gorm:"column:name"`
+	Address Address `gorm:"column:address;type:struct(street varchar, city varchar);serializer:struct"`
+}
+
+type Shipment struct {
+	ID        uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	ShippedAt time.Time  `gorm:"column:shipped_at;type:timestamptz;serializer:timezone" timezone:"America/Los_Angeles"`
+	ArrivedAt *time.Time `gorm:"column:arrived_at;type:timestamptz;serializer:timezone" timezone:"America/Los_Angeles"`
+}
+
+// TestStructSerializerLiteral verifies the STRUCT literal rendered for a nested struct.
+func TestStructSerializerLiteral(t *testing.T) {
+	value, err := duckdb.StructSerializer{}.Value(context.Background(), nil, reflect.Value{}, Address{Street: "King's Road", City: "London"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{'city': 'London', 'street': 'King\'s Road'}`, value)
+}
+
+// TestStructSerializerRoundTrip verifies a nested struct survives a round-trip through a STRUCT column.
+func TestStructSerializerRoundTrip(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&CustomerWithAddress{}))
+
+	var columnType string
+	assert.NoError(t, db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = ? AND column_name = ?",
+		"customer_with_addresses", "address").Scan(&columnType).Error)
+	assert.Contains(t, columnType, "STRUCT")
+
+	customer := CustomerWithAddress{Name: "Alice", Address: Address{Street: "Main St", City: "Springfield"}}
+	assert.NoError(t, db.Create(&customer).Error)
+
+	var found CustomerWithAddress
+	assert.NoError(t, db.First(&found, customer.ID).Error)
+	assert.Equal(t, customer.Address, found.Address)
+
+	var city string
+	assert.NoError(t, db.Raw("SELECT address.city FROM customer_with_addresses WHERE id = ?", customer.ID).Scan(&city).Error)
+	assert.Equal(t, "Springfield", city)
+}
+
+type GeoPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type Landmark struct {
+	ID       uint     `gorm:"column:id;primaryKey;autoIncrement"`
+	Name     string   `gorm:"column:name"`
+	Location GeoPoint `gorm:"column:location;type:struct(x double, y double);serializer:struct"`
+}
+
+// TestStructColumnMigration verifies the STRUCT declaration is used verbatim, kept by AutoMigrate and scanned back.
+func TestStructColumnMigration(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Landmark{}))
+	assert.NoError(t, db.AutoMigrate(&Landmark{}))
+
+	var columnType string
+	assert.NoError(t, db.Raw("SELECT data_type FROM duckdb_columns() WHERE table_name = ? AND column_name = ?", "landmarks", "location").
+		Row().Scan(&columnType))
+	assert.Equal(t, "STRUCT(x DOUBLE, y DOUBLE)", columnType)
+
+	assert.NoError(t, db.Create(&[]Landmark{
+		{Name: "tower", Location: GeoPoint{X: 2.2945, Y: 48.8584}},
+		{Name: "origin"},
+	}).Error)
+
+	var landmarks []Landmark
+	assert.NoError(t, db.Raw("SELECT * FROM landmarks ORDER BY id").Scan(&landmarks).Error)
+	assert.Len(t, landmarks, 2)
+	assert.Equal(t, GeoPoint{X: 2.2945, Y: 48.8584}, landmarks[0].Location)
+	assert.Equal(t, GeoPoint{}, landmarks[1].Location)
+
+	var y float64
+	assert.NoError(t, db.Raw("SELECT location.y FROM landmarks WHERE name = ?", "tower").Row().Scan(&y))
+	assert.Equal(t, 48.8584, y)
+}
+
+// TestTimezoneSerializer verifies a time stored in EST is kept as UTC and read back as the same instant in PST.
+func TestTimezoneSerializer(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	est, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.AutoMigrate(&Shipment{}))
+
+	shippedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, est)
+	assert.NoError(t, db.Create(&Shipment{ShippedAt: shippedAt}).Error)
+
+	var utc time.Time
+	assert.NoError(t, db.Model(&Shipment{}).Select("?", duckdb.InTimezone("shipped_at", "UTC")).Scan(&utc).Error)
+	assert.Equal(t, 14, utc.Hour())
+
+	var shipment Shipment
+	assert.NoError(t, db.First(&shipment).Error)
+	assert.True(t, shippedAt.Equal(shipment.ShippedAt))
+	assert.Equal(t, "America/Los_Angeles", shipment.ShippedAt.Location().String())
+	assert.Equal(t, 6, shipment.ShippedAt.Hour())
+	assert.Nil(t, shipment.ArrivedAt)
+}
+
+type Cardholder struct {
+	ID    uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Email string `gorm:"column:email"`
+	Card  string `gorm:"column:card"`
+}
+
+type MaskedCardholder struct {
+	ID    uint    `gorm:"column:id;primaryKey;autoIncrement"`
+	Email string  `gorm:"column:email;serializer:mask_email"`
+	Card  *string `gorm:"column:card;serializer:mask_cc"`
+}
+
+func (MaskedCardholder) TableName() string {
+	return "cardholders"
+}
+
+// TestMaskingSerializer verifies masked reads while the stored values stay unchanged.
+func TestMaskingSerializer(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Cardholder{}))
+	assert.NoError(t, db.Create(&Cardholder{Email: "alice@example.com", Card: "4111 1111 1111 1234"}).Error)
+
+	var masked MaskedCardholder
+	assert.NoError(t, db.First(&masked).Error)
+	assert.Equal(t, "a***@example.com", masked.Email)
+	assert.Equal(t, "****-****-****-1234", *masked.Card)
+
+	var raw Cardholder
+	assert.NoError(t, db.First(&raw).Error)
+	assert.Equal(t, "alice@example.com", raw.Email)
+	assert.Equal(t, "4111 1111 1111 1234", raw.Card)
+
+	assert.Equal(t, "***", duckdb.MaskEmail("not-an-email"))
+	assert.Equal(t, "****", duckdb.MaskCreditCard("12"))
+}
@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RefreshStatistics runs ANALYZE on value's table, a model or a table name,
+// to recompute the statistics the optimizer plans with, e.g. after a large bulk insert.
+func RefreshStatistics(db *gorm.DB, value interface{}) error {
+	table, err := tableNameOf(db, value)
+	if err != nil {
+		return err
+	}
+	return db.Exec("ANALYZE ?", clause.Table{Name: table}).Error
+}
+
+// RefreshAllStatistics runs ANALYZE on every table of the database.
+func RefreshAllStatistics(db *gorm.DB) error {
+	return db.Exec("ANALYZE").Error
+}
+
+// tableNameOf returns the table of value, a model or a table name.
+func tableNameOf(db *gorm.DB, value interface{}) (string, error) {
+	if table, ok := value.(string); ok {
+		return table, nil
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(value); err != nil {
+		return "", err
+	}
+	return stmt.Table, nil
+}
+
+// indexEntryBytes is the estimated size of one index entry, a row id plus ART node overhead.
+const indexEntryBytes = 16
+
+// TableSizeInfo is the estimated on-disk size of a table.
+type TableSizeInfo struct {
+	TableName  string
+	TotalBytes int64
+	IndexBytes int64
+	TableBytes int64
+}
+
+// GetTableSize estimates the on-disk size of value's table, a model or a table name.
+// TableBytes counts the storage blocks holding the table's checkpointed segments, so rows still
+// in the WAL are not included until the next CHECKPOINT. DuckDB doesn't report index storage,
+// IndexBytes assumes 16 bytes per row for each index listed by duckdb_indexes().
+func GetTableSize(db *gorm.DB, value interface{}) (TableSizeInfo, error) {
+	var info TableSizeInfo
+	m := db.Migrator().(Migrator)
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		info.TableName = stmt.Table
+		currentSchema, table := m.CurrentSchema(stmt, stmt.Table)
+		catalog := m.currentCatalog(stmt, stmt.Table)
+
+		var blockSize int64
+		if err := db.Raw("SELECT block_size FROM pragma_database_size() WHERE database_name = ?", catalog).Row().Scan(&blockSize); err != nil {
+			return err
+		}
+
+		var blocks int64
+		if err := db.Raw("SELECT count(DISTINCT block_id) FROM pragma_storage_info(?) WHERE persistent AND block_id >= 0",
+			stringLiteral(storageName(catalog, currentSchema, table))).Row().Scan(&blocks); err != nil {
+			return err
+		}
+		info.TableBytes = blocks * blockSize
+
+		var rows, indexes int64
+		if err := db.Raw("SELECT estimated_size FROM duckdb_tables() WHERE database_name = ? AND schema_name = ? AND table_name = ?",
+			catalog, currentSchema, table).Row().Scan(&rows); err != nil {
+			return err
+		}
+		if err := db.Raw("SELECT count(*) FROM duckdb_indexes() WHERE database_name = ? AND schema_name = ? AND table_name = ?",
+			catalog, currentSchema, table).Row().Scan(&indexes); err != nil {
+			return err
+		}
+		info.IndexBytes = rows * indexes * indexEntryBytes
+
+		info.TotalBytes = info.TableBytes + info.IndexBytes
+		return nil
+	})
+	return info, err
+}
+
+// GetDatabaseSize returns the sum of GetTableSize's TotalBytes over the tables of the current schema.
+func GetDatabaseSize(db *gorm.DB) (int64, error) {
+	var tables []string
+	if err := db.Raw("SELECT table_name FROM duckdb_tables() WHERE database_name = current_database() AND schema_name = current_schema() AND NOT internal").
+		Scan(&tables).Error; err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, table := range tables {
+		info, err := GetTableSize(db, table)
+		if err != nil {
+			return 0, err
+		}
+		total += info.TotalBytes
+	}
+	return total, nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+// TestRefreshStatistics verifies the estimated size reported by duckdb_tables() after a bulk insert and ANALYZE.
+func TestRefreshStatistics(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 100000)
+
+	assert.NoError(t, duckdb.RefreshStatistics(db, &SampleRow{}))
+	assert.NoError(t, duckdb.RefreshStatistics(db, "sample_rows"))
+
+	var size int64
+	assert.NoError(t, db.Raw("SELECT estimated_size FROM duckdb_tables() WHERE table_name = ?", "sample_rows").Scan(&size).Error)
+	assert.Equal(t, int64(100000), size)
+
+	assert.NoError(t, db.Exec("INSERT INTO sample_rows SELECT range::INTEGER FROM range(100000, 150000)").Error)
+	assert.NoError(t, duckdb.RefreshAllStatistics(db))
+
+	assert.NoError(t, db.Raw("SELECT estimated_size FROM duckdb_tables() WHERE table_name = ?", "sample_rows").Scan(&size).Error)
+	assert.Equal(t, int64(150000), size)
+}
+
+// TestGetTableSize verifies a checkpointed table and its index report a positive size.
+func TestGetTableSize(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+	initSampleRows(t, db, 100000)
+
+	assert.NoError(t, db.Exec("CREATE INDEX idx_sample_rows_id ON sample_rows (id)").Error)
+	assert.NoError(t, db.Exec("CHECKPOINT").Error)
+
+	info, err := duckdb.GetTableSize(db, &SampleRow{})
+	assert.NoError(t, err)
+	assert.Equal(t, "sample_rows", info.TableName)
+	assert.Greater(t, info.TableBytes, int64(0))
+	assert.Equal(t, int64(100000*16), info.IndexBytes)
+	assert.Equal(t, info.TableBytes+info.IndexBytes, info.TotalBytes)
+
+	size, err := duckdb.GetDatabaseSize(db)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, size, info.TotalBytes)
+
+	assert.NoError(t, db.Exec("CREATE SCHEMA archive").Error)
+	assert.NoError(t, db.Exec("CREATE TABLE archive.sample_rows AS SELECT range::INTEGER AS id FROM range(10)").Error)
+	assert.NoError(t, db.Exec("CREATE INDEX idx_archive_sample_rows_id ON archive.sample_rows (id)").Error)
+	assert.NoError(t, db.Exec("CHECKPOINT").Error)
+
+	archived, err := duckdb.GetTableSize(db, "archive.sample_rows")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10*16), archived.IndexBytes)
+
+	_, err = db.Migrator().(duckdb.Migrator).GetTableSize("missing")
+	assert.Error(t, err)
+}
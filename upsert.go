@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// buildOnConflict builds ON CONFLICT with a conflict target inferred from the unique fields
+// of the model when none is given, as DuckDB requires one for DO UPDATE on tables with more
+// than one unique constraint. The primary key target gorm sets for UpdateAll is replaced too
+// when the inserted rows leave the key to its default, as it could never conflict then.
+func buildOnConflict(c clause.Clause, builder clause.Builder) {
+	onConflict, ok := c.Expression.(clause.OnConflict)
+	stmt, isStmt := builder.(*gorm.Statement)
+	if !ok || !isStmt || stmt.Schema == nil || onConflict.OnConstraint != "" {
+		c.Build(builder)
+		return
+	}
+
+	if len(onConflict.Columns) == 0 || (isPrimaryKeyTarget(stmt.Schema, onConflict.Columns) && !insertsPrimaryKey(stmt)) {
+		if columns := uniqueConflictColumns(stmt.Schema); len(columns) > 0 {
+			onConflict.Columns = columns
+		}
+	}
+
+	_, _ = builder.WriteString("ON CONFLICT ")
+	onConflict.Build(builder)
+}
+
+func isPrimaryKeyTarget(s *schema.Schema, columns []clause.Column) bool {
+	if len(columns) == 0 || len(columns) != len(s.PrimaryFields) {
+		return false
+	}
+	for idx, field := range s.PrimaryFields {
+		if columns[idx].Name != field.DBName {
+			return false
+		}
+	}
+	return true
+}
+
+func insertsPrimaryKey(stmt *gorm.Statement) bool {
+	values, ok := stmt.Clauses["VALUES"].Expression.(clause.Values)
+	if !ok {
+		return true
+	}
+	for _, column := range values.Columns {
+		if field := stmt.Schema.LookUpField(column.Name); field != nil && field.PrimaryKey {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueConflictColumns returns the columns of the first unique index of s, or else
+// of its first unique field, skipping the primary key.
+func uniqueConflictColumns(s *schema.Schema) []clause.Column {
+	for _, index := range s.ParseIndexes() {
+		if index.Class != "UNIQUE" {
+			continue
+		}
+		columns := make([]clause.Column, 0, len(index.Fields))
+		for _, option := range index.Fields {
+			columns = append(columns, clause.Column{Name: option.DBName})
+		}
+		return columns
+	}
+
+	for _, field := range s.Fields {
+		if field.Unique && !field.PrimaryKey && field.DBName != "" {
+			return []clause.Column{{Name: field.DBName}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Subscriber struct {
+	ID    uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	Email string `gorm:"column:email;uniqueIndex"`
+	Name  string `gorm:"column:name"`
+}
+
+// TestUpsertUniqueIndex verifies upserts conflicting on a unique index update the existing row.
+func TestUpsertUniqueIndex(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Subscriber{}))
+	first := Subscriber{Email: "ann@example.com", Name: "Ann"}
+	assert.NoError(t, db.Create(&first).Error)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&Subscriber{Email: "ann@example.com", Name: "Annie"})
+	})
+	assert.Contains(t, sql, "ON CONFLICT (email) DO UPDATE SET")
+
+	assert.NoError(t, db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&Subscriber{Email: "ann@example.com", Name: "Annie"}).Error)
+	assert.NoError(t, db.Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"name"})}).
+		Create(&[]Subscriber{{Email: "ann@example.com", Name: "Ann B."}, {Email: "bob@example.com", Name: "Bob"}}).Error)
+	assert.NoError(t, db.Clauses(clause.OnConflict{DoNothing: true}).Create(&Subscriber{Email: "bob@example.com", Name: "Robert"}).Error)
+
+	var subscribers []Subscriber
+	assert.NoError(t, db.Order("email").Find(&subscribers).Error)
+	assert.Len(t, subscribers, 2)
+	assert.Equal(t, first.ID, subscribers[0].ID)
+	assert.Equal(t, "Ann B.", subscribers[0].Name)
+	assert.Equal(t, "Bob", subscribers[1].Name)
+
+	withID := Subscriber{ID: first.ID, Email: "ann@example.com", Name: "By id"}
+	assert.NoError(t, db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&withID).Error)
+	var count int64
+	assert.NoError(t, db.Model(&Subscriber{}).Where("name = ?", "By id").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// uuidDataType is the gorm data type of UUID fields, mapped to a DuckDB UUID by DataTypeOf.
+const uuidDataType schema.DataType = "uuid"
+
+// UUIDDefault returns gen_random_uuid(), the DuckDB expression generating a random v4 UUID,
+// e.g. to set it as a column default.
+func UUIDDefault() clause.Expr {
+	return clause.Expr{SQL: "gen_random_uuid()"}
+}
+
+// UUID is a field type for DuckDB UUID columns. The zero UUID is written as NULL, so a
+// column with a default gets a generated value:
+//
+//	ID duckdb.UUID `gorm:"primaryKey;default:gen_random_uuid()"`
+type UUID [16]byte
+
+// UUIDPrimaryKey can be embedded in a model to give it an ID generated by DuckDB.
+type UUIDPrimaryKey struct {
+	ID UUID `gorm:"column:id;primaryKey;default:gen_random_uuid()"`
+}
+
+func (UUID) GormDataType() string {
+	return string(uuidDataType)
+}
+
+// String formats the UUID as xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// IsZero reports whether the UUID is all zeros.
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+func (u UUID) Value() (driver.Value, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// Scan accepts the 16 bytes the driver returns for UUID columns as well as UUID strings.
+func (u *UUID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*u = UUID{}
+		return nil
+	case []byte:
+		if len(v) == len(u) {
+			copy(u[:], v)
+			return nil
+		}
+		return u.parse(string(v))
+	case string:
+		return u.parse(v)
+	default:
+		return fmt.Errorf("failed to scan %T into %T", src, u)
+	}
+}
+
+func (u *UUID) parse(str string) error {
+	if len(str) != 36 || str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		return fmt.Errorf("invalid UUID %q", str)
+	}
+
+	digits := str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	var parsed UUID
+	if _, err := hex.Decode(parsed[:], []byte(digits)); err != nil {
+		return fmt.Errorf("invalid UUID %q: %w", str, err)
+	}
+	*u = parsed
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+)
+
+type Device struct {
+	duckdb.UUIDPrimaryKey
+	Name string `gorm:"column:name"`
+}
+
+// TestUUIDPrimaryKey verifies DuckDB generates v4 UUIDs for rows inserted without one.
+func TestUUIDPrimaryKey(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&Device{}))
+
+	var columnType string
+	assert.NoError(t, db.Raw("SELECT data_type FROM duckdb_columns() WHERE table_name = ? AND column_name = ?", "devices", "id").Row().Scan(&columnType))
+	assert.Equal(t, "UUID", columnType)
+
+	devices := []Device{{Name: "sensor"}, {Name: "camera"}}
+	assert.NoError(t, db.Create(&devices).Error)
+	assert.False(t, devices[0].ID.IsZero())
+	assert.NotEqual(t, devices[0].ID, devices[1].ID)
+
+	var found []Device
+	assert.NoError(t, db.Order("name").Find(&found).Error)
+	assert.Len(t, found, 2)
+	for _, device := range found {
+		assert.Equal(t, byte(4), device.ID[6]>>4, device.ID.String())
+		assert.Equal(t, byte(0x80), device.ID[8]&0xc0, device.ID.String())
+	}
+
+	var device Device
+	assert.NoError(t, db.First(&device, "id = ?", devices[1].ID).Error)
+	assert.Equal(t, "camera", device.Name)
+
+	var generated string
+	assert.NoError(t, db.Raw("SELECT ?::VARCHAR", duckdb.UUIDDefault()).Row().Scan(&generated))
+	var parsed duckdb.UUID
+	assert.NoError(t, parsed.Scan(generated))
+	assert.Equal(t, generated, parsed.String())
+}
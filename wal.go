@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Checkpoint runs CHECKPOINT, writing the WAL of the current database into its main file.
+func Checkpoint(db *gorm.DB) error {
+	return db.Exec("CHECKPOINT").Error
+}
+
+// ForceCheckpoint runs FORCE CHECKPOINT. Since DuckDB 1.4 it waits for the running transactions
+// of other connections to finish instead of failing because of them, so it blocks for as long as
+// one of them stays open.
+func ForceCheckpoint(db *gorm.DB) error {
+	return db.Exec("FORCE CHECKPOINT").Error
+}
+
+// CheckpointError is returned by SafeCheckpoint when CHECKPOINT fails, e.g. because another
+// connection holds an open write transaction.
+type CheckpointError struct {
+	Checkpoint error
+}
+
+func (e *CheckpointError) Error() string {
+	return fmt.Sprintf("checkpoint failed: %v", e.Checkpoint)
+}
+
+func (e *CheckpointError) Unwrap() error {
+	return e.Checkpoint
+}
+
+// SafeCheckpoint runs CHECKPOINT and returns failures as *CheckpointError. Transactions blocking
+// the checkpoint belong to other connections of the pool and can't be rolled back from here,
+// finish them and retry, or use ForceCheckpoint to wait for them.
+func SafeCheckpoint(db *gorm.DB) error {
+	if err := db.Exec("CHECKPOINT").Error; err != nil {
+		return &CheckpointError{Checkpoint: err}
+	}
+	return nil
+}
+
+// WALPath returns the WAL file of the current database, empty for an in-memory database.
+func WALPath(db *gorm.DB) (string, error) {
+	var path sql.NullString
+	if err := db.Raw("SELECT path FROM duckdb_databases() WHERE database_name = current_database()").Row().Scan(&path); err != nil {
+		return "", err
+	}
+	if !path.Valid || path.String == "" {
+		return "", nil
+	}
+	return path.String + ".wal", nil
+}
+
+// WALWatcher checkpoints the database whenever its WAL file grows past a threshold.
+type WALWatcher struct {
+	db          *gorm.DB
+	threshold   int64
+	interval    time.Duration
+	stop        chan struct{}
+	done        chan struct{}
+	stopOnce    sync.Once
+	checkpoints int64
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewWALWatcher starts a goroutine checking the WAL size of db every interval and running
+// Checkpoint once it exceeds threshold bytes. Call Stop to terminate it.
+// Nothing is checkpointed for an in-memory database, which has no WAL file.
+func NewWALWatcher(db *gorm.DB, threshold int64, interval time.Duration) *WALWatcher {
+	w := &WALWatcher{
+		db:        db,
+		threshold: threshold,
+		interval:  interval,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *WALWatcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.check(); err != nil {
+				w.mu.Lock()
+				w.lastErr = err
+				w.mu.Unlock()
+			}
+		}
+	}
+}
+
+func (w *WALWatcher) check() error {
+	path, err := WALPath(w.db)
+	if err != nil || path == "" {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Size() <= w.threshold {
+		return nil
+	}
+
+	if err := Checkpoint(w.db); err != nil {
+		return err
+	}
+	atomic.AddInt64(&w.checkpoints, 1)
+	return nil
+}
+
+// Checkpoints returns how many checkpoints the watcher has run.
+func (w *WALWatcher) Checkpoints() int64 {
+	return atomic.LoadInt64(&w.checkpoints)
+}
+
+// Err returns the last error met while checking the WAL or checkpointing, if any.
+func (w *WALWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lastErr
+}
+
+// Stop terminates the watcher goroutine and waits for it to return, it is safe to call more than once.
+func (w *WALWatcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
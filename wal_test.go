@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestWALWatcher verifies the watcher checkpoints once the WAL outgrows the threshold.
+func TestWALWatcher(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	path, err := duckdb.WALPath(db)
+	assert.NoError(t, err)
+	assert.Equal(t, "test.db.wal", filepath.Base(path))
+
+	assert.NoError(t, db.AutoMigrate(&PoolItem{}))
+	assert.NoError(t, duckdb.Checkpoint(db))
+
+	watcher := duckdb.NewWALWatcher(db, 1024, 10*time.Millisecond)
+	defer watcher.Stop()
+
+	items := make([]PoolItem, 1000)
+	for i := range items {
+		items[i] = PoolItem{Name: "wal item", Qty: i}
+	}
+	assert.NoError(t, db.CreateInBatches(&items, 100).Error)
+
+	assert.Eventually(t, func() bool { return watcher.Checkpoints() > 0 }, 5*time.Second, 10*time.Millisecond)
+	watcher.Stop()
+	assert.NoError(t, watcher.Err())
+
+	info, err := os.Stat(path)
+	if err == nil {
+		assert.LessOrEqual(t, info.Size(), int64(1024))
+	}
+}
+
+// TestSafeCheckpoint verifies a clean database checkpoints and a failing checkpoint is reported as CheckpointError.
+func TestSafeCheckpoint(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.AutoMigrate(&PoolItem{}))
+	assert.NoError(t, db.Create(&PoolItem{Name: "bolt", Qty: 1}).Error)
+	assert.NoError(t, duckdb.SafeCheckpoint(db))
+	assert.NoError(t, duckdb.ForceCheckpoint(db))
+
+	closed, err := gorm.Open(duckdb.Open(filepath.Join(t.TempDir(), "closed.db")), &gorm.Config{})
+	assert.NoError(t, err)
+	sqlDB, err := closed.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, sqlDB.Close())
+
+	err = duckdb.SafeCheckpoint(closed)
+	var checkpointErr *duckdb.CheckpointError
+	assert.ErrorAs(t, err, &checkpointErr)
+	if checkpointErr != nil {
+		assert.Error(t, checkpointErr.Checkpoint)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb
+
+import (
+	"strconv"
+
+	"gorm.io/gorm/clause"
+)
+
+// Frame builds the frame of a window function, e.g.
+//
+//	frame := duckdb.Frame{}.Range().Preceding("INTERVAL 6 DAYS").CurrentRow()
+//	db.Select("day, avg(value) OVER (ORDER BY day ?) AS rolling", frame)
+//
+// renders RANGE BETWEEN INTERVAL 6 DAYS PRECEDING AND CURRENT ROW. The first boundary starts
+// the frame and the second, if any, ends it. The mode defaults to ROWS.
+type Frame struct {
+	mode   string
+	bounds []clause.Expr
+}
+
+// Rows counts the offsets of the boundaries in rows.
+func (f Frame) Rows() Frame {
+	f.mode = "ROWS"
+	return f
+}
+
+// Range measures the offsets of the boundaries in values of the ORDER BY column.
+func (f Frame) Range() Frame {
+	f.mode = "RANGE"
+	return f
+}
+
+// Groups counts the offsets of the boundaries in groups of peer rows.
+func (f Frame) Groups() Frame {
+	f.mode = "GROUPS"
+	return f
+}
+
+// Preceding adds an n PRECEDING boundary. Integers are written as is, strings as raw SQL
+// such as INTERVAL 1 DAY, and anything else as a var.
+func (f Frame) Preceding(n interface{}) Frame {
+	return f.bound(offset(n), " PRECEDING")
+}
+
+// Following adds an n FOLLOWING boundary, n is written as for Preceding.
+func (f Frame) Following(n interface{}) Frame {
+	return f.bound(offset(n), " FOLLOWING")
+}
+
+// CurrentRow adds a CURRENT ROW boundary.
+func (f Frame) CurrentRow() Frame {
+	return f.bound(clause.Expr{SQL: "CURRENT ROW"}, "")
+}
+
+// UnboundedPreceding adds an UNBOUNDED PRECEDING boundary.
+func (f Frame) UnboundedPreceding() Frame {
+	return f.bound(clause.Expr{SQL: "UNBOUNDED"}, " PRECEDING")
+}
+
+// UnboundedFollowing adds an UNBOUNDED FOLLOWING boundary.
+func (f Frame) UnboundedFollowing() Frame {
+	return f.bound(clause.Expr{SQL: "UNBOUNDED"}, " FOLLOWING")
+}
+
+func (f Frame) bound(expr clause.Expr, direction string) Frame {
+	expr.SQL += direction
+	f.bounds = append(f.bounds[:len(f.bounds):len(f.bounds)], expr)
+	return f
+}
+
+func offset(n interface{}) clause.Expr {
+	switch v := n.(type) {
+	case int:
+		return clause.Expr{SQL: strconv.Itoa(v)}
+	case int64:
+		return clause.Expr{SQL: strconv.FormatInt(v, 10)}
+	case string:
+		return clause.Expr{SQL: v}
+	default:
+		return clause.Expr{SQL: "?", Vars: []interface{}{v}}
+	}
+}
+
+// Build implements clause.Expression
+func (f Frame) Build(builder clause.Builder) {
+	mode := f.mode
+	if mode == "" {
+		mode = "ROWS"
+	}
+	_, _ = builder.WriteString(mode)
+
+	switch len(f.bounds) {
+	case 0:
+		_, _ = builder.WriteString(" UNBOUNDED PRECEDING")
+	case 1:
+		_ = builder.WriteByte(' ')
+		f.bounds[0].Build(builder)
+	default:
+		_, _ = builder.WriteString(" BETWEEN ")
+		f.bounds[0].Build(builder)
+		_, _ = builder.WriteString(" AND ")
+		f.bounds[1].Build(builder)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package duckdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// TestFrameSQL verifies the rendered frame clauses.
+func TestFrameSQL(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	cases := map[string]duckdb.Frame{
+		"ROWS BETWEEN 3 PRECEDING AND CURRENT ROW":               duckdb.Frame{}.Rows().Preceding(3).CurrentRow(),
+		"RANGE BETWEEN INTERVAL 1 DAY PRECEDING AND CURRENT ROW": duckdb.Frame{}.Range().Preceding("INTERVAL 1 DAY").CurrentRow(),
+		"GROUPS BETWEEN UNBOUNDED PRECEDING AND 1 FOLLOWING":     duckdb.Frame{}.Groups().UnboundedPreceding().Following(1),
+		"ROWS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING":       duckdb.Frame{}.CurrentRow().UnboundedFollowing(),
+		"ROWS UNBOUNDED PRECEDING":                               duckdb.Frame{},
+	}
+	for expected, frame := range cases {
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Table("t").Select("sum(x) OVER (ORDER BY x ?)", frame).Find(&[]map[string]interface{}{})
+		})
+		assert.Contains(t, sql, "OVER (ORDER BY x "+expected+")")
+	}
+}
+
+// TestFrameRollingAverage verifies a 7-day RANGE frame against a manual rolling average over days with gaps.
+func TestFrameRollingAverage(t *testing.T) {
+	db := initDB(t)
+	defer closeDB(t, db)
+
+	assert.NoError(t, db.Exec("CREATE TABLE daily (day DATE, value DOUBLE)").Error)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	values := map[int]float64{}
+	for i := 0; i < 20; i++ {
+		if i%4 == 3 {
+			continue
+		}
+		values[i] = float64(i * i)
+		assert.NoError(t, db.Exec("INSERT INTO daily VALUES (?, ?)", start.AddDate(0, 0, i), values[i]).Error)
+	}
+
+	var rows []struct {
+		Day     time.Time
+		Rolling float64
+	}
+	frame := duckdb.Frame{}.Range().Preceding("INTERVAL 6 DAYS").CurrentRow()
+	err := db.Table("daily").Select("day, avg(value) OVER (ORDER BY day ?) AS rolling", frame).Order("day").Find(&rows).Error
+	assert.NoError(t, err)
+	assert.Len(t, rows, len(values))
+
+	for _, row := range rows {
+		day := int(row.Day.Sub(start).Hours() / 24)
+		var sum, count float64
+		for d := day - 6; d <= day; d++ {
+			if v, ok := values[d]; ok {
+				sum += v
+				count++
+			}
+		}
+		assert.InDelta(t, sum/count, row.Rolling, 1e-9, row.Day)
+	}
+}